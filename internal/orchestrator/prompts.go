@@ -309,32 +309,18 @@ agent_output:
 - handoff.next_agent should be "none" if no further work needed
 `
 
-// GetSquadPrompt returns the base prompt for a squad.
+// defaultSquadPrompts is the package-level SquadPromptRegistry backing
+// GetSquadPrompt, pre-populated with the built-in prompts above. Projects
+// that want to add a squad or override one of these wholesale should call
+// defaultSquadPrompts.Register/Extend or LoadProjectOverrides rather than
+// editing this file.
+var defaultSquadPrompts = NewSquadPromptRegistry()
+
+// GetSquadPrompt returns the composed prompt for a squad: its base prompt
+// (built-in, or replaced via Register), any `.claude/squads/<squad>.md`
+// project override, and any appendix added via Extend.
 func GetSquadPrompt(squad AgentSquad) string {
-	switch squad {
-	case SquadFrontend:
-		return FrontendSquadPrompt
-	case SquadBackend:
-		return BackendSquadPrompt
-	case SquadData:
-		return DataSquadPrompt
-	case SquadBusiness:
-		return BusinessSquadPrompt
-	case SquadDevOps:
-		return DevOpsSquadPrompt
-	case SquadQA:
-		return QASquadPrompt
-	case SquadPerformance:
-		return PerformanceSquadPrompt
-	case SquadDocumentation:
-		return DocumentationSquadPrompt
-	case SquadAccessibility:
-		return AccessibilitySquadPrompt
-	case SquadAI:
-		return AISquadPrompt
-	default:
-		return fmt.Sprintf("# %s Expert\n\nYou are a specialist in your domain.\n", squad)
-	}
+	return defaultSquadPrompts.Prompt(squad)
 }
 
 // GetAgentPrompt returns a complete prompt for a specific agent.
@@ -0,0 +1,140 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// SquadPromptRegistry holds the base prompt for each squad plus any
+// project-specific layering on top of it, replacing the old hardcoded
+// switch in GetSquadPrompt. It lets a project register an entirely new
+// squad, override a built-in one, or extend one with an appendix, without
+// forking this package.
+type SquadPromptRegistry struct {
+	mu        sync.RWMutex
+	base      map[AgentSquad]string
+	overrides map[AgentSquad]string
+	appendix  map[AgentSquad]string
+}
+
+// NewSquadPromptRegistry creates a registry pre-populated with the module's
+// built-in squad prompts.
+func NewSquadPromptRegistry() *SquadPromptRegistry {
+	return &SquadPromptRegistry{
+		base: map[AgentSquad]string{
+			SquadFrontend:      FrontendSquadPrompt,
+			SquadBackend:       BackendSquadPrompt,
+			SquadData:          DataSquadPrompt,
+			SquadBusiness:      BusinessSquadPrompt,
+			SquadDevOps:        DevOpsSquadPrompt,
+			SquadQA:            QASquadPrompt,
+			SquadPerformance:   PerformanceSquadPrompt,
+			SquadDocumentation: DocumentationSquadPrompt,
+			SquadAccessibility: AccessibilitySquadPrompt,
+			SquadAI:            AISquadPrompt,
+		},
+		overrides: make(map[AgentSquad]string),
+		appendix:  make(map[AgentSquad]string),
+	}
+}
+
+// Register sets (or replaces) the base prompt for a squad, letting a project
+// define a squad this package doesn't ship, or replace a built-in one
+// wholesale (e.g. a project that doesn't use the assumed Supabase/Drizzle
+// stack in BackendSquadPrompt).
+func (r *SquadPromptRegistry) Register(squad AgentSquad, prompt string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.base[squad] = prompt
+}
+
+// Extend appends project-specific addenda after a squad's base prompt
+// instead of replacing it. Calling Extend more than once for the same squad
+// concatenates each appendix in call order.
+func (r *SquadPromptRegistry) Extend(squad AgentSquad, appendix string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.appendix[squad]; ok && existing != "" {
+		r.appendix[squad] = existing + "\n\n" + appendix
+	} else {
+		r.appendix[squad] = appendix
+	}
+}
+
+// Prompt returns the fully composed prompt for a squad: its base prompt,
+// then any `.claude/squads/<squad>.md` override loaded via
+// LoadProjectOverrides, then any appendix added via Extend. A squad with no
+// base prompt registered falls back to the same generic placeholder the old
+// switch's default case used.
+func (r *SquadPromptRegistry) Prompt(squad AgentSquad) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	base, ok := r.base[squad]
+	if !ok {
+		base = fmt.Sprintf("# %s Expert\n\nYou are a specialist in your domain.\n", squad)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(base)
+
+	if override := r.overrides[squad]; override != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(override)
+	}
+
+	if appendix := r.appendix[squad]; appendix != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(appendix)
+	}
+
+	return sb.String()
+}
+
+// squadOverrideFrontmatterPattern matches the same `---\n...\n---\n` block
+// skills use, kept local to this package. LoadProjectOverrides strips a
+// matched frontmatter block and does not read any of its fields.
+var squadOverrideFrontmatterPattern = regexp.MustCompile(`(?s)^---\s*\n(.+?)\n---\s*\n(.*)$`)
+
+// LoadProjectOverrides loads `.claude/squads/<squad>.md` overrides for every
+// known squad from projectRoot, mirroring the project>user>local layering
+// the skills package already does for skill sources. A squad with no
+// override file is left untouched; a missing `.claude/squads` directory is
+// not an error.
+func (r *SquadPromptRegistry) LoadProjectOverrides(projectRoot string) error {
+	dir := filepath.Join(projectRoot, ".claude", "squads")
+
+	r.mu.RLock()
+	squads := make([]AgentSquad, 0, len(r.base))
+	for squad := range r.base {
+		squads = append(squads, squad)
+	}
+	r.mu.RUnlock()
+
+	for _, squad := range squads {
+		path := filepath.Join(dir, string(squad)+".md")
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to read squad override %s: %w", path, err)
+		}
+
+		body := string(content)
+		if matches := squadOverrideFrontmatterPattern.FindStringSubmatch(body); len(matches) == 3 {
+			body = matches[2]
+		}
+
+		r.mu.Lock()
+		r.overrides[squad] = strings.TrimSpace(body)
+		r.mu.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Note: AgentSquad and the Squad* constants (SquadFrontend, SquadBackend,
+// ...) referenced below are assumed types from the larger module this
+// snapshot is a fragment of - see squad_registry.go and prompts.go, which
+// already reference them without defining them anywhere in this tree. This
+// file exercises SquadPromptRegistry the same way the real module's callers
+// would.
+
+func TestSquadPromptRegistryReturnsBuiltinBasePrompt(t *testing.T) {
+	registry := NewSquadPromptRegistry()
+
+	got := registry.Prompt(SquadFrontend)
+	if got != FrontendSquadPrompt {
+		t.Errorf("Prompt(SquadFrontend) = %q, want the built-in FrontendSquadPrompt", got)
+	}
+}
+
+func TestSquadPromptRegistryFallsBackForUnknownSquad(t *testing.T) {
+	registry := NewSquadPromptRegistry()
+
+	got := registry.Prompt(AgentSquad("unknown-squad"))
+	want := "# unknown-squad Expert\n\nYou are a specialist in your domain.\n"
+	if got != want {
+		t.Errorf("Prompt(unknown) = %q, want %q", got, want)
+	}
+}
+
+func TestSquadPromptRegistryRegisterReplacesBasePrompt(t *testing.T) {
+	registry := NewSquadPromptRegistry()
+
+	registry.Register(SquadFrontend, "custom frontend prompt")
+	if got, want := registry.Prompt(SquadFrontend), "custom frontend prompt"; got != want {
+		t.Errorf("Prompt(SquadFrontend) after Register = %q, want %q", got, want)
+	}
+}
+
+func TestSquadPromptRegistryExtendAppendsInCallOrder(t *testing.T) {
+	registry := NewSquadPromptRegistry()
+
+	registry.Extend(SquadBackend, "first addendum")
+	registry.Extend(SquadBackend, "second addendum")
+
+	got := registry.Prompt(SquadBackend)
+	want := BackendSquadPrompt + "\n\n" + "first addendum" + "\n\n" + "second addendum"
+	if got != want {
+		t.Errorf("Prompt(SquadBackend) = %q, want %q", got, want)
+	}
+}
+
+func TestSquadPromptRegistryLoadProjectOverridesStripsFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	squadsDir := filepath.Join(dir, ".claude", "squads")
+	if err := os.MkdirAll(squadsDir, 0o755); err != nil {
+		t.Fatalf("failed to create squads dir: %v", err)
+	}
+	content := "---\nnote: internal\n---\noverride body\n"
+	overridePath := filepath.Join(squadsDir, string(SquadFrontend)+".md")
+	if err := os.WriteFile(overridePath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write override file: %v", err)
+	}
+
+	registry := NewSquadPromptRegistry()
+	if err := registry.LoadProjectOverrides(dir); err != nil {
+		t.Fatalf("LoadProjectOverrides returned unexpected error: %v", err)
+	}
+
+	got := registry.Prompt(SquadFrontend)
+	want := FrontendSquadPrompt + "\n\n" + "override body"
+	if got != want {
+		t.Errorf("Prompt(SquadFrontend) after LoadProjectOverrides = %q, want %q", got, want)
+	}
+}
+
+func TestSquadPromptRegistryLoadProjectOverridesIgnoresMissingDirectory(t *testing.T) {
+	registry := NewSquadPromptRegistry()
+	if err := registry.LoadProjectOverrides(t.TempDir()); err != nil {
+		t.Fatalf("expected a missing .claude/squads directory to be a no-op, got error: %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+package skills
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxUseSkillDepth bounds use_skill recursion so a cycle (or just very deep
+// composition) can't hang prompt assembly.
+const maxUseSkillDepth = 8
+
+var (
+	includeDirectivePattern     = regexp.MustCompile(`\{\{include\s+"([^"]+)"\s*\}\}`)
+	includeCodeDirectivePattern = regexp.MustCompile(`\{\{include_code\s+"([^"]+)"((?:\s+\w+=\S+)*)\s*\}\}`)
+	useSkillDirectivePattern    = regexp.MustCompile(`\{\{use_skill\s+"([^"]+)"((?:\s+\S+)*)\s*\}\}`)
+)
+
+// langByExt maps a file extension to the fence language include_code uses
+// when lang= isn't given explicitly.
+var langByExt = map[string]string{
+	".go": "go", ".ts": "typescript", ".tsx": "tsx", ".js": "javascript",
+	".py": "python", ".rs": "rust", ".md": "markdown", ".json": "json",
+	".yaml": "yaml", ".yml": "yaml", ".sh": "bash",
+}
+
+// ExpandDirectives resolves the composition directives a skill's Content
+// may contain - {{include "path"}}, {{include_code "path" lang=.. lines=a-b}},
+// and {{use_skill "name" args...}} - before ExpandVariables runs. allowRoot
+// bounds every include/include_code path so a skill can't read outside its
+// project with "../../etc/passwd"; pass "" to default to the skill's own
+// directory.
+func (i *SkillInvoker) ExpandDirectives(skill *Skill, sc *SkillContext, allowRoot string) (string, error) {
+	return i.expandDirectives(skill, sc, allowRoot, 0, map[string]bool{strings.ToLower(skill.Name): true})
+}
+
+// expandDirectives is ExpandDirectives' recursive implementation. visiting
+// tracks skill names already on the use_skill call stack for cycle
+// detection, and depth enforces maxUseSkillDepth.
+func (i *SkillInvoker) expandDirectives(skill *Skill, sc *SkillContext, allowRoot string, depth int, visiting map[string]bool) (string, error) {
+	if depth > maxUseSkillDepth {
+		return "", fmt.Errorf("use_skill: max composition depth (%d) exceeded", maxUseSkillDepth)
+	}
+
+	content := skill.Content
+
+	content, err := i.expandIncludes(skill, allowRoot, content)
+	if err != nil {
+		return "", err
+	}
+
+	content, err = i.expandUseSkill(sc, allowRoot, depth, visiting, content)
+	if err != nil {
+		return "", err
+	}
+
+	return content, nil
+}
+
+func (i *SkillInvoker) expandIncludes(skill *Skill, allowRoot, content string) (string, error) {
+	var firstErr error
+
+	content = includeDirectivePattern.ReplaceAllStringFunc(content, func(match string) string {
+		path := includeDirectivePattern.FindStringSubmatch(match)[1]
+
+		resolved, err := resolveIncludePath(skill.Path, allowRoot, path)
+		if err != nil {
+			firstErr = recordFirstErr(firstErr, err)
+			return match
+		}
+		data, err := os.ReadFile(resolved)
+		if err != nil {
+			firstErr = recordFirstErr(firstErr, fmt.Errorf("include %q: %w", path, err))
+			return match
+		}
+		return string(data)
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	content = includeCodeDirectivePattern.ReplaceAllStringFunc(content, func(match string) string {
+		groups := includeCodeDirectivePattern.FindStringSubmatch(match)
+		path, attrs := groups[1], parseDirectiveAttrs(groups[2])
+
+		resolved, err := resolveIncludePath(skill.Path, allowRoot, path)
+		if err != nil {
+			firstErr = recordFirstErr(firstErr, err)
+			return match
+		}
+		data, err := os.ReadFile(resolved)
+		if err != nil {
+			firstErr = recordFirstErr(firstErr, fmt.Errorf("include_code %q: %w", path, err))
+			return match
+		}
+
+		snippet := string(data)
+		if lines := attrs["lines"]; lines != "" {
+			snippet, err = sliceLines(snippet, lines)
+			if err != nil {
+				firstErr = recordFirstErr(firstErr, fmt.Errorf("include_code %q: %w", path, err))
+				return match
+			}
+		}
+
+		lang := attrs["lang"]
+		if lang == "" {
+			lang = langByExt[strings.ToLower(filepath.Ext(path))]
+		}
+
+		return "```" + lang + "\n" + strings.TrimRight(snippet, "\n") + "\n```"
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	return content, nil
+}
+
+func (i *SkillInvoker) expandUseSkill(sc *SkillContext, allowRoot string, depth int, visiting map[string]bool, content string) (string, error) {
+	var firstErr error
+
+	content = useSkillDirectivePattern.ReplaceAllStringFunc(content, func(match string) string {
+		groups := useSkillDirectivePattern.FindStringSubmatch(match)
+		name, args := groups[1], strings.TrimSpace(groups[2])
+		key := strings.ToLower(name)
+
+		if visiting[key] {
+			firstErr = recordFirstErr(firstErr, fmt.Errorf("use_skill %q: cycle detected", name))
+			return match
+		}
+
+		other := i.registry.Get(name)
+		if other == nil {
+			var err error
+			other, err = i.loader.LoadSkillByName(name)
+			if err != nil {
+				firstErr = recordFirstErr(firstErr, fmt.Errorf("use_skill %q: %w", name, err))
+				return match
+			}
+		}
+
+		nextVisiting := make(map[string]bool, len(visiting)+1)
+		for k := range visiting {
+			nextVisiting[k] = true
+		}
+		nextVisiting[key] = true
+
+		expanded, err := i.expandDirectives(other, sc, allowRoot, depth+1, nextVisiting)
+		if err != nil {
+			firstErr = recordFirstErr(firstErr, err)
+			return match
+		}
+
+		return sc.ExpandVariables(buildSkillPrompt(other, expanded, args))
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	return content, nil
+}
+
+// recordFirstErr keeps the first error encountered across a
+// ReplaceAllStringFunc pass, since that callback can't return an error
+// itself.
+func recordFirstErr(existing, next error) error {
+	if existing != nil {
+		return existing
+	}
+	return next
+}
+
+// resolveIncludePath resolves rel against the skill file's own directory
+// and rejects any result that escapes allowRoot (or the skill's directory,
+// if allowRoot is "").
+func resolveIncludePath(skillPath, allowRoot, rel string) (string, error) {
+	base := filepath.Dir(skillPath)
+	resolved := filepath.Clean(filepath.Join(base, rel))
+
+	root := allowRoot
+	if root == "" {
+		root = base
+	}
+	root = filepath.Clean(root)
+
+	relToRoot, err := filepath.Rel(root, resolved)
+	if err != nil || relToRoot == ".." || strings.HasPrefix(relToRoot, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q escapes allowed root %q", rel, root)
+	}
+
+	return resolved, nil
+}
+
+// parseDirectiveAttrs parses `key=value` pairs out of a directive's
+// trailing attribute string (e.g. `lang=go lines=10-40`).
+func parseDirectiveAttrs(raw string) map[string]string {
+	attrs := make(map[string]string)
+	for _, field := range strings.Fields(raw) {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) == 2 {
+			attrs[parts[0]] = parts[1]
+		}
+	}
+	return attrs
+}
+
+// sliceLines returns the "start-end" (1-indexed, inclusive) or single-line
+// slice of content that include_code's lines= attribute requests.
+func sliceLines(content, rangeSpec string) (string, error) {
+	parts := strings.SplitN(rangeSpec, "-", 2)
+
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid lines range %q", rangeSpec)
+	}
+
+	end := start
+	if len(parts) == 2 {
+		if end, err = strconv.Atoi(parts[1]); err != nil {
+			return "", fmt.Errorf("invalid lines range %q", rangeSpec)
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	if start < 1 {
+		start = 1
+	}
+	if end > len(lines) {
+		end = len(lines)
+	}
+	if start > end {
+		return "", nil
+	}
+
+	return strings.Join(lines[start-1:end], "\n"), nil
+}
@@ -0,0 +1,151 @@
+package skills
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveIncludePath(t *testing.T) {
+	tests := []struct {
+		name      string
+		skillPath string
+		allowRoot string
+		rel       string
+		wantErr   bool
+	}{
+		{
+			name:      "sibling file within skill directory",
+			skillPath: "/project/.claude/skills/deploy/skill.md",
+			allowRoot: "",
+			rel:       "checklist.md",
+			wantErr:   false,
+		},
+		{
+			name:      "nested path within skill directory",
+			skillPath: "/project/.claude/skills/deploy/skill.md",
+			allowRoot: "",
+			rel:       "snippets/staging.md",
+			wantErr:   false,
+		},
+		{
+			name:      "escapes the skill directory with no allowRoot",
+			skillPath: "/project/.claude/skills/deploy/skill.md",
+			allowRoot: "",
+			rel:       "../../../../etc/passwd",
+			wantErr:   true,
+		},
+		{
+			name:      "escapes an explicit allowRoot",
+			skillPath: "/project/.claude/skills/deploy/skill.md",
+			allowRoot: "/project/.claude/skills",
+			rel:       "../../etc/passwd",
+			wantErr:   true,
+		},
+		{
+			name:      "stays within an explicit allowRoot wider than the skill directory",
+			skillPath: "/project/.claude/skills/deploy/skill.md",
+			allowRoot: "/project/.claude/skills",
+			rel:       "../other-skill/shared.md",
+			wantErr:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveIncludePath(tt.skillPath, tt.allowRoot, tt.rel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveIncludePath(%q, %q, %q) error = %v, wantErr %v", tt.skillPath, tt.allowRoot, tt.rel, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpandDirectivesResolvesInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeSkillFile(t, dir, "checklist.md", "- [ ] run tests\n")
+
+	skill := &Skill{Name: "deploy", Path: filepath.Join(dir, "skill.md"), Content: `{{include "checklist.md"}}`}
+	invoker := NewSkillInvoker(NewSkillRegistry(), NewSkillLoader(NewSkillRegistry()))
+
+	got, err := invoker.ExpandDirectives(skill, NewSkillContext(context.Background(), dir), "")
+	if err != nil {
+		t.Fatalf("ExpandDirectives returned unexpected error: %v", err)
+	}
+	if want := "- [ ] run tests\n"; got != want {
+		t.Errorf("ExpandDirectives() = %q, want %q", got, want)
+	}
+}
+
+func TestExpandDirectivesResolvesIncludeCode(t *testing.T) {
+	dir := t.TempDir()
+	writeSkillFile(t, dir, "main.go", "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n")
+
+	skill := &Skill{Name: "review", Path: filepath.Join(dir, "skill.md"), Content: `{{include_code "main.go" lines=1-2}}`}
+	invoker := NewSkillInvoker(NewSkillRegistry(), NewSkillLoader(NewSkillRegistry()))
+
+	got, err := invoker.ExpandDirectives(skill, NewSkillContext(context.Background(), dir), "")
+	if err != nil {
+		t.Fatalf("ExpandDirectives returned unexpected error: %v", err)
+	}
+	if want := "```go\npackage main\n```"; got != want {
+		t.Errorf("ExpandDirectives() = %q, want %q", got, want)
+	}
+}
+
+func TestExpandDirectivesResolvesUseSkill(t *testing.T) {
+	registry := NewSkillRegistry()
+	registry.Register(&Skill{Name: "greet", Content: "Hello {{name}}"})
+
+	skill := &Skill{Name: "outer", Content: `{{use_skill "greet" name=world}}`}
+	invoker := NewSkillInvoker(registry, NewSkillLoader(registry))
+
+	sc := NewSkillContext(context.Background(), ".")
+	sc.Variables["name"] = "world"
+	got, err := invoker.ExpandDirectives(skill, sc, "")
+	if err != nil {
+		t.Fatalf("ExpandDirectives returned unexpected error: %v", err)
+	}
+	if !containsSubstring(got, "Hello world") {
+		t.Errorf("ExpandDirectives() = %q, want it to contain %q", got, "Hello world")
+	}
+}
+
+func TestExpandDirectivesDetectsUseSkillCycle(t *testing.T) {
+	registry := NewSkillRegistry()
+	registry.Register(&Skill{Name: "a", Content: `{{use_skill "b"}}`})
+	registry.Register(&Skill{Name: "b", Content: `{{use_skill "a"}}`})
+	invoker := NewSkillInvoker(registry, NewSkillLoader(registry))
+
+	_, err := invoker.ExpandDirectives(registry.Get("a"), NewSkillContext(context.Background(), "."), "")
+	if err == nil {
+		t.Fatal("expected a cycle-detection error, got nil")
+	}
+	if !containsSubstring(err.Error(), "cycle") {
+		t.Errorf("error = %q, want it to mention a cycle", err.Error())
+	}
+}
+
+func TestExpandDirectivesEnforcesMaxUseSkillDepth(t *testing.T) {
+	registry := NewSkillRegistry()
+	for i := 0; i <= maxUseSkillDepth+1; i++ {
+		registry.Register(&Skill{
+			Name:    skillChainName(i),
+			Content: `{{use_skill "` + skillChainName(i+1) + `"}}`,
+		})
+	}
+	registry.Register(&Skill{Name: skillChainName(maxUseSkillDepth + 2), Content: "bottom"})
+	invoker := NewSkillInvoker(registry, NewSkillLoader(registry))
+
+	_, err := invoker.ExpandDirectives(registry.Get(skillChainName(0)), NewSkillContext(context.Background(), "."), "")
+	if err == nil {
+		t.Fatal("expected a max-depth error, got nil")
+	}
+	if !containsSubstring(err.Error(), "max composition depth") {
+		t.Errorf("error = %q, want it to mention the max composition depth", err.Error())
+	}
+}
+
+func skillChainName(i int) string {
+	return "chain" + string(rune('a'+i))
+}
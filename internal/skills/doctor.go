@@ -0,0 +1,276 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severity classifies how serious a DiagnosticEntry is.
+type Severity string
+
+const (
+	SeverityInfo  Severity = "info"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+)
+
+// sourcePrecedence mirrors the project>user>local priority LoadSkillByName
+// already uses, so the doctor reports the same winner a real load would.
+var sourcePrecedence = map[SkillSource]int{
+	SourceProject: 0,
+	SourceUser:    1,
+	SourceLocal:   2,
+}
+
+// builtinVariables are resolved by SkillContext.ExpandVariables itself, so
+// they're never "unresolved" even though they look like references.
+var builtinVariables = map[string]bool{
+	"cwd":          true,
+	"project_root": true,
+	"current_file": true,
+	"selection":    true,
+}
+
+// knownToolNames are the tool names Diagnose recognizes without flagging a
+// warning. Anything else is still allowed at runtime (a project may wire up
+// custom tools) but is surfaced so authors can catch typos.
+var knownToolNames = map[string]bool{
+	"*":    true,
+	"read": true, "write": true, "edit": true,
+	"bash": true, "grep": true, "glob": true, "task": true,
+}
+
+// variableRefPattern matches the skill package's legacy "{{var}}" placeholder
+// syntax (see SkillContext.ExpandVariables).
+var variableRefPattern = regexp.MustCompile(`\{\{([a-zA-Z_][a-zA-Z0-9_]*)\}\}`)
+
+// variableRefPatternBash matches the bash-style "${var}", "${var:-default}",
+// "${var:+alt}", and "${var/pattern/replacement}" placeholder syntax that
+// ExpandVariables supports alongside "{{var}}" (see the substituteDollarBrace*
+// family in template.go).
+var variableRefPatternBash = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)(?:[:/][^}]*)?\}`)
+
+// DiagnosticEntry reports the result of examining a single skill file.
+type DiagnosticEntry struct {
+	Source              SkillSource `json:"source"`
+	Path                string      `json:"path"`
+	Name                string      `json:"name,omitempty"`
+	Severity            Severity    `json:"severity"`
+	Message             string      `json:"message"`
+	MissingFields       []string    `json:"missing_fields,omitempty"`
+	UnresolvedVariables []string    `json:"unresolved_variables,omitempty"`
+	UnknownTools        []string    `json:"unknown_tools,omitempty"`
+}
+
+// DiagnosticDuplicate reports a skill name registered from more than one
+// source, and which source wins under project>user>local precedence.
+type DiagnosticDuplicate struct {
+	Name    string        `json:"name"`
+	Winner  SkillSource   `json:"winner"`
+	Sources []SkillSource `json:"sources"`
+}
+
+// DiagnosticReport is the structured result of SkillLoader.Diagnose.
+type DiagnosticReport struct {
+	Entries    []DiagnosticEntry     `json:"entries"`
+	Duplicates []DiagnosticDuplicate `json:"duplicates,omitempty"`
+}
+
+// HasErrors reports whether any entry in the report is SeverityError.
+func (r *DiagnosticReport) HasErrors() bool {
+	for _, e := range r.Entries {
+		if e.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Diagnose walks every configured source directory and returns a structured
+// report of parse failures, missing frontmatter, unresolved "{{...}}"
+// variables, unknown AllowedTools entries, and skill names duplicated
+// across sources. Unlike LoadAll/loadFromDirectory it never registers
+// anything, and it never swallows a per-file problem into a stdout print.
+//
+// This is the library surface a `crush skills doctor [--verbose]` command
+// would call into - Render below produces exactly the text such a command
+// would print - but no cmd/ package or flag parsing exists anywhere in this
+// tree yet, so wiring it up to an actual CLI is still open.
+func (l *SkillLoader) Diagnose(ctx context.Context) (*DiagnosticReport, error) {
+	report := &DiagnosticReport{}
+	nameSources := make(map[string][]SkillSource)
+
+	for source, dir := range l.sources {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		info, err := os.Stat(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to stat directory: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("not a directory: %s", dir)
+		}
+
+		err = filepath.Walk(dir, func(path string, fi os.FileInfo, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if fi.IsDir() {
+				return nil
+			}
+			ext := strings.ToLower(filepath.Ext(path))
+			if ext != ".md" && ext != ".markdown" {
+				return nil
+			}
+
+			entry := l.diagnoseFile(source, path)
+			report.Entries = append(report.Entries, entry)
+			if entry.Name != "" {
+				nameSources[entry.Name] = append(nameSources[entry.Name], source)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk %s (%s): %w", source, dir, err)
+		}
+	}
+
+	for name, sources := range nameSources {
+		if len(sources) < 2 {
+			continue
+		}
+		sort.Slice(sources, func(i, j int) bool {
+			return sourcePrecedence[sources[i]] < sourcePrecedence[sources[j]]
+		})
+		report.Duplicates = append(report.Duplicates, DiagnosticDuplicate{
+			Name:    name,
+			Winner:  sources[0],
+			Sources: sources,
+		})
+	}
+	sort.Slice(report.Duplicates, func(i, j int) bool {
+		return report.Duplicates[i].Name < report.Duplicates[j].Name
+	})
+
+	return report, nil
+}
+
+// diagnoseFile examines a single skill file without registering it.
+func (l *SkillLoader) diagnoseFile(source SkillSource, path string) DiagnosticEntry {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return DiagnosticEntry{Source: source, Path: path, Severity: SeverityError, Message: fmt.Sprintf("failed to read file: %v", err)}
+	}
+
+	frontmatter, body, err := extractFrontmatter(string(content))
+	if err != nil {
+		return DiagnosticEntry{Source: source, Path: path, Severity: SeverityError, Message: fmt.Sprintf("failed to extract frontmatter: %v", err)}
+	}
+
+	meta, err := parseFrontmatterYAML(frontmatter)
+	if err != nil {
+		meta, err = parseFrontmatterPermissive(frontmatter)
+		if err != nil {
+			return DiagnosticEntry{Source: source, Path: path, Severity: SeverityError, Message: fmt.Sprintf("failed to parse frontmatter: %v", err)}
+		}
+	}
+
+	var missing []string
+	if meta.Name == "" {
+		missing = append(missing, "name")
+	}
+	if meta.Description == "" {
+		missing = append(missing, "description")
+	}
+
+	var unresolved []string
+	seenUnresolved := make(map[string]bool)
+	addUnresolved := func(name string) {
+		if builtinVariables[name] || seenUnresolved[name] {
+			return
+		}
+		seenUnresolved[name] = true
+		unresolved = append(unresolved, name)
+	}
+	for _, match := range variableRefPattern.FindAllStringSubmatch(body, -1) {
+		addUnresolved(match[1])
+	}
+	for _, match := range variableRefPatternBash.FindAllStringSubmatch(body, -1) {
+		addUnresolved(match[1])
+	}
+
+	var unknownTools []string
+	for _, tool := range meta.AllowedTools {
+		rule := parseToolRule(tool)
+		if !knownToolNames[strings.ToLower(rule.Name)] {
+			unknownTools = append(unknownTools, tool)
+		}
+	}
+
+	severity := SeverityInfo
+	var messages []string
+	if len(missing) > 0 {
+		severity = SeverityError
+		messages = append(messages, fmt.Sprintf("missing required frontmatter field(s): %s", strings.Join(missing, ", ")))
+	}
+	if len(unresolved) > 0 {
+		if severity == SeverityInfo {
+			severity = SeverityWarn
+		}
+		messages = append(messages, fmt.Sprintf("references undefined variable(s): %s", strings.Join(unresolved, ", ")))
+	}
+	if len(unknownTools) > 0 {
+		if severity == SeverityInfo {
+			severity = SeverityWarn
+		}
+		messages = append(messages, fmt.Sprintf("references unrecognized tool(s): %s", strings.Join(unknownTools, ", ")))
+	}
+	if len(messages) == 0 {
+		messages = append(messages, "ok")
+	}
+
+	return DiagnosticEntry{
+		Source:              source,
+		Path:                path,
+		Name:                meta.Name,
+		Severity:            severity,
+		Message:             strings.Join(messages, "; "),
+		MissingFields:       missing,
+		UnresolvedVariables: unresolved,
+		UnknownTools:        unknownTools,
+	}
+}
+
+// Render formats the report as plain text, one line per entry prefixed with
+// its severity, for `crush skills doctor [--verbose]` to print. Non-verbose
+// mode omits SeverityInfo entries so a clean skills directory prints
+// nothing but the duplicate summary, if any.
+func (r *DiagnosticReport) Render(verbose bool) string {
+	var sb strings.Builder
+
+	for _, e := range r.Entries {
+		if !verbose && e.Severity == SeverityInfo {
+			continue
+		}
+		fmt.Fprintf(&sb, "[%s] %s (%s): %s\n", strings.ToUpper(string(e.Severity)), e.Path, e.Source, e.Message)
+	}
+
+	for _, d := range r.Duplicates {
+		fmt.Fprintf(&sb, "[WARN] skill %q defined in %v, using %s\n", d.Name, d.Sources, d.Winner)
+	}
+
+	if sb.Len() == 0 {
+		return "no issues found\n"
+	}
+	return sb.String()
+}
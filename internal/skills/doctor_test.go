@@ -0,0 +1,165 @@
+package skills
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSkillFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write skill file: %v", err)
+	}
+}
+
+func diagnoseSingleSource(t *testing.T, content string) DiagnosticEntry {
+	t.Helper()
+	dir := t.TempDir()
+	writeSkillFile(t, dir, "skill.md", content)
+
+	loader := NewSkillLoader(NewSkillRegistry())
+	loader.AddSource(SourceProject, dir)
+
+	report, err := loader.Diagnose(context.Background())
+	if err != nil {
+		t.Fatalf("Diagnose returned unexpected error: %v", err)
+	}
+	if len(report.Entries) != 1 {
+		t.Fatalf("expected exactly one entry, got %d", len(report.Entries))
+	}
+	return report.Entries[0]
+}
+
+func TestDiagnoseFlagsMissingRequiredFrontmatter(t *testing.T) {
+	entry := diagnoseSingleSource(t, "---\n---\nbody\n")
+
+	if entry.Severity != SeverityError {
+		t.Fatalf("Severity = %q, want %q", entry.Severity, SeverityError)
+	}
+	if len(entry.MissingFields) != 2 {
+		t.Fatalf("MissingFields = %v, want name and description", entry.MissingFields)
+	}
+}
+
+func TestDiagnoseFlagsUnresolvedLegacyVariable(t *testing.T) {
+	content := "---\nname: greet\ndescription: says hello\n---\nHello {{username}}\n"
+	entry := diagnoseSingleSource(t, content)
+
+	if entry.Severity != SeverityWarn {
+		t.Fatalf("Severity = %q, want %q", entry.Severity, SeverityWarn)
+	}
+	if len(entry.UnresolvedVariables) != 1 || entry.UnresolvedVariables[0] != "username" {
+		t.Fatalf("UnresolvedVariables = %v, want [username]", entry.UnresolvedVariables)
+	}
+}
+
+func TestDiagnoseFlagsUnresolvedBashStyleVariable(t *testing.T) {
+	content := "---\nname: greet\ndescription: says hello\n---\nHello ${username:-world}\n"
+	entry := diagnoseSingleSource(t, content)
+
+	if entry.Severity != SeverityWarn {
+		t.Fatalf("Severity = %q, want %q", entry.Severity, SeverityWarn)
+	}
+	if len(entry.UnresolvedVariables) != 1 || entry.UnresolvedVariables[0] != "username" {
+		t.Fatalf("UnresolvedVariables = %v, want [username]", entry.UnresolvedVariables)
+	}
+}
+
+func TestDiagnoseDedupesVariableReferencedInBothSyntaxes(t *testing.T) {
+	content := "---\nname: greet\ndescription: says hello\n---\nHi {{username}}, bye ${username}\n"
+	entry := diagnoseSingleSource(t, content)
+
+	if len(entry.UnresolvedVariables) != 1 || entry.UnresolvedVariables[0] != "username" {
+		t.Fatalf("UnresolvedVariables = %v, want [username] exactly once", entry.UnresolvedVariables)
+	}
+}
+
+func TestDiagnoseIgnoresBuiltinVariables(t *testing.T) {
+	content := "---\nname: greet\ndescription: says hello\n---\nworking in {{cwd}} at {{project_root}}\n"
+	entry := diagnoseSingleSource(t, content)
+
+	if entry.Severity != SeverityInfo {
+		t.Fatalf("Severity = %q, want %q", entry.Severity, SeverityInfo)
+	}
+	if len(entry.UnresolvedVariables) != 0 {
+		t.Fatalf("UnresolvedVariables = %v, want none", entry.UnresolvedVariables)
+	}
+}
+
+func TestDiagnoseFlagsUnknownToolIncludingCallStyle(t *testing.T) {
+	content := "---\nname: greet\ndescription: says hello\nallowed-tools: [Bash(git:*), Frobnicate]\n---\nbody\n"
+	entry := diagnoseSingleSource(t, content)
+
+	if len(entry.UnknownTools) != 1 || entry.UnknownTools[0] != "Frobnicate" {
+		t.Fatalf("UnknownTools = %v, want [Frobnicate] (call-style Bash(git:*) should be recognized)", entry.UnknownTools)
+	}
+}
+
+func TestDiagnoseCleanSkillIsInfoOK(t *testing.T) {
+	content := "---\nname: greet\ndescription: says hello\n---\nHello there\n"
+	entry := diagnoseSingleSource(t, content)
+
+	if entry.Severity != SeverityInfo {
+		t.Fatalf("Severity = %q, want %q", entry.Severity, SeverityInfo)
+	}
+	if entry.Message != "ok" {
+		t.Fatalf("Message = %q, want %q", entry.Message, "ok")
+	}
+}
+
+func TestDiagnoseReportsDuplicatesAcrossSourcesWithProjectWinning(t *testing.T) {
+	projectDir, userDir := t.TempDir(), t.TempDir()
+	content := "---\nname: greet\ndescription: says hello\n---\nbody\n"
+	writeSkillFile(t, projectDir, "skill.md", content)
+	writeSkillFile(t, userDir, "skill.md", content)
+
+	loader := NewSkillLoader(NewSkillRegistry())
+	loader.AddSource(SourceProject, projectDir)
+	loader.AddSource(SourceUser, userDir)
+
+	report, err := loader.Diagnose(context.Background())
+	if err != nil {
+		t.Fatalf("Diagnose returned unexpected error: %v", err)
+	}
+	if len(report.Duplicates) != 1 {
+		t.Fatalf("expected exactly one duplicate, got %d", len(report.Duplicates))
+	}
+	if report.Duplicates[0].Winner != SourceProject {
+		t.Fatalf("Winner = %q, want %q", report.Duplicates[0].Winner, SourceProject)
+	}
+}
+
+func TestRenderOmitsInfoEntriesUnlessVerbose(t *testing.T) {
+	report := &DiagnosticReport{Entries: []DiagnosticEntry{
+		{Severity: SeverityInfo, Path: "clean.md", Message: "ok"},
+		{Severity: SeverityWarn, Path: "warn.md", Message: "references undefined variable(s): x"},
+	}}
+
+	quiet := report.Render(false)
+	if containsSubstring(quiet, "clean.md") {
+		t.Errorf("non-verbose Render included an info entry: %q", quiet)
+	}
+	if !containsSubstring(quiet, "warn.md") {
+		t.Errorf("non-verbose Render dropped a warn entry: %q", quiet)
+	}
+
+	verbose := report.Render(true)
+	if !containsSubstring(verbose, "clean.md") {
+		t.Errorf("verbose Render dropped an info entry: %q", verbose)
+	}
+}
+
+func containsSubstring(haystack, needle string) bool {
+	return len(needle) == 0 || (len(haystack) >= len(needle) && indexOf(haystack, needle) >= 0)
+}
+
+func indexOf(haystack, needle string) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		if haystack[i:i+len(needle)] == needle {
+			return i
+		}
+	}
+	return -1
+}
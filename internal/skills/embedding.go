@@ -0,0 +1,235 @@
+package skills
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Embedder produces vector embeddings for a batch of texts. Implementations
+// typically adapt one of the AI providers the orchestrator already talks to
+// (e.g. wrapping an embeddings endpoint behind this interface).
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// ScoredSkill pairs a skill with its cosine similarity to a search query.
+type ScoredSkill struct {
+	Skill *Skill  `json:"skill"`
+	Score float32 `json:"score"`
+}
+
+// vectorCacheEntry is the on-disk representation of a single skill's cached
+// embedding. It is keyed by content hash plus LastModified so an edited
+// skill is automatically re-embedded instead of serving a stale vector.
+type vectorCacheEntry struct {
+	Hash         string    `json:"hash"`
+	LastModified time.Time `json:"last_modified"`
+	Vector       []float32 `json:"vector"`
+}
+
+// EnableSemanticSearch configures the registry to maintain an embedding
+// index alongside the plain substring index. cacheDir is where the vector
+// cache is persisted (e.g. "~/.claude/skills/.index") so restarts don't
+// re-embed every skill; pass "" to keep the cache in memory only.
+func (r *SkillRegistry) EnableSemanticSearch(embedder Embedder, cacheDir string) error {
+	r.vectorsMu.Lock()
+	defer r.vectorsMu.Unlock()
+
+	r.embedder = embedder
+	r.vectors = make(map[string]vectorCacheEntry)
+
+	if cacheDir == "" {
+		return nil
+	}
+	r.indexPath = filepath.Join(cacheDir, "vectors.json")
+
+	data, err := os.ReadFile(r.indexPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read vector cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &r.vectors); err != nil {
+		return fmt.Errorf("failed to parse vector cache: %w", err)
+	}
+
+	return nil
+}
+
+// embedSkill computes and caches the embedding for a skill, skipping the
+// call to Embed if a valid cache entry already exists. It is invoked
+// asynchronously from Register so skill loading never blocks on it.
+func (r *SkillRegistry) embedSkill(skill *Skill) {
+	r.vectorsMu.RLock()
+	embedder := r.embedder
+	r.vectorsMu.RUnlock()
+
+	if embedder == nil {
+		return
+	}
+
+	name := strings.ToLower(skill.Name)
+	hash := skillContentHash(skill)
+
+	r.vectorsMu.RLock()
+	entry, ok := r.vectors[name]
+	r.vectorsMu.RUnlock()
+	if ok && entry.Hash == hash && entry.LastModified.Equal(skill.LastModified) {
+		return // cache is current
+	}
+
+	vectors, err := embedder.Embed(context.Background(), []string{skillEmbeddingText(skill)})
+	if err != nil || len(vectors) == 0 {
+		return
+	}
+
+	r.vectorsMu.Lock()
+	r.vectors[name] = vectorCacheEntry{
+		Hash:         hash,
+		LastModified: skill.LastModified,
+		Vector:       vectors[0],
+	}
+	r.vectorsMu.Unlock()
+
+	r.persistVectorCache()
+}
+
+// persistVectorCache writes the in-memory vector cache to disk. Failures are
+// non-fatal: the cache is best-effort and will simply be rebuilt next run.
+func (r *SkillRegistry) persistVectorCache() {
+	if r.indexPath == "" {
+		return
+	}
+
+	r.vectorsMu.RLock()
+	data, err := json.Marshal(r.vectors)
+	r.vectorsMu.RUnlock()
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.indexPath), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(r.indexPath, data, 0o644)
+}
+
+// SearchSemantic ranks registered skills by cosine similarity between their
+// cached embedding and the query's embedding, returning the topK matches
+// scoring at least minScore. Skills with no cached vector yet are left out of
+// the ranking. It falls back to the plain substring Search (unranked, Score 0,
+// not limited by topK) when no embedder is configured, when the query embeds
+// to no vector, or when no ranked skill scores at least minScore. An error
+// from the embedder while embedding the query is returned as is.
+func (r *SkillRegistry) SearchSemantic(ctx context.Context, query string, topK int, minScore float32) ([]ScoredSkill, error) {
+	r.vectorsMu.RLock()
+	embedder := r.embedder
+	r.vectorsMu.RUnlock()
+
+	if embedder == nil {
+		return scoredFromSubstringSearch(r.Search(query)), nil
+	}
+
+	queryVectors, err := embedder.Embed(ctx, []string{query})
+	if err != nil || len(queryVectors) == 0 {
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed query: %w", err)
+		}
+		return scoredFromSubstringSearch(r.Search(query)), nil
+	}
+	queryVector := queryVectors[0]
+
+	r.mu.RLock()
+	skills := make([]*Skill, 0, len(r.skills))
+	for _, skill := range r.skills {
+		skills = append(skills, skill)
+	}
+	r.mu.RUnlock()
+
+	r.vectorsMu.RLock()
+	var matches []ScoredSkill
+	for _, skill := range skills {
+		entry, ok := r.vectors[strings.ToLower(skill.Name)]
+		if !ok {
+			continue
+		}
+		score := cosineSimilarity(queryVector, entry.Vector)
+		if score >= minScore {
+			matches = append(matches, ScoredSkill{Skill: skill, Score: score})
+		}
+	}
+	r.vectorsMu.RUnlock()
+
+	if len(matches) == 0 {
+		return scoredFromSubstringSearch(r.Search(query)), nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	if topK > 0 && len(matches) > topK {
+		matches = matches[:topK]
+	}
+
+	return matches, nil
+}
+
+// scoredFromSubstringSearch wraps a plain Search result as ScoredSkill, used
+// as the fallback when semantic search is unavailable or inconclusive.
+func scoredFromSubstringSearch(skills []*Skill) []ScoredSkill {
+	scored := make([]ScoredSkill, len(skills))
+	for i, skill := range skills {
+		scored[i] = ScoredSkill{Skill: skill, Score: 0}
+	}
+	return scored
+}
+
+// skillEmbeddingText builds the text embedded for a skill, combining its
+// name, description, and frontmatter tags.
+func skillEmbeddingText(skill *Skill) string {
+	text := skill.Name + "\n" + skill.Description
+	if len(skill.Tags) > 0 {
+		text += "\n" + strings.Join(skill.Tags, " ")
+	}
+	return text
+}
+
+// skillContentHash hashes a skill's embedded text so cache entries can be
+// invalidated when that text changes.
+func skillContentHash(skill *Skill) string {
+	sum := sha256.Sum256([]byte(skillEmbeddingText(skill)))
+	return hex.EncodeToString(sum[:])
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors, or 0 if
+// either is empty or they differ in length.
+func cosineSimilarity(a, b []float32) float32 {
+	if len(a) == 0 || len(b) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
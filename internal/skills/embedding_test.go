@@ -0,0 +1,115 @@
+package skills
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float32
+	}{
+		{name: "identical vectors", a: []float32{1, 0, 0}, b: []float32{1, 0, 0}, want: 1},
+		{name: "orthogonal vectors", a: []float32{1, 0}, b: []float32{0, 1}, want: 0},
+		{name: "opposite vectors", a: []float32{1, 0}, b: []float32{-1, 0}, want: -1},
+		{name: "empty a", a: nil, b: []float32{1, 0}, want: 0},
+		{name: "empty b", a: []float32{1, 0}, b: nil, want: 0},
+		{name: "mismatched length", a: []float32{1, 0}, b: []float32{1, 0, 0}, want: 0},
+		{name: "zero vector", a: []float32{0, 0}, b: []float32{1, 1}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cosineSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("cosineSimilarity(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkillEmbeddingTextIncludesTagsWhenPresent(t *testing.T) {
+	withoutTags := &Skill{Name: "deploy", Description: "ship code to prod"}
+	if got, want := skillEmbeddingText(withoutTags), "deploy\nship code to prod"; got != want {
+		t.Errorf("skillEmbeddingText() = %q, want %q", got, want)
+	}
+
+	withTags := &Skill{Name: "deploy", Description: "ship code to prod", Tags: []string{"ci", "release"}}
+	if got, want := skillEmbeddingText(withTags), "deploy\nship code to prod\nci release"; got != want {
+		t.Errorf("skillEmbeddingText() = %q, want %q", got, want)
+	}
+}
+
+func TestSkillContentHashChangesWithEmbeddingText(t *testing.T) {
+	a := &Skill{Name: "deploy", Description: "ship code to prod"}
+	b := &Skill{Name: "deploy", Description: "ship code to staging"}
+
+	if skillContentHash(a) == skillContentHash(b) {
+		t.Error("expected different embedding text to produce different hashes")
+	}
+	if skillContentHash(a) != skillContentHash(a) {
+		t.Error("expected skillContentHash to be deterministic for the same skill")
+	}
+}
+
+// fakeEmbedder returns a fixed vector per text, so tests can control
+// cosine similarity outcomes deterministically.
+type fakeEmbedder struct {
+	vectors map[string][]float32
+}
+
+func (f *fakeEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i] = f.vectors[text]
+	}
+	return out, nil
+}
+
+func TestSearchSemanticRanksByCosineSimilarity(t *testing.T) {
+	registry := NewSkillRegistry()
+	deploy := &Skill{Name: "deploy", Description: "ship code to prod"}
+	review := &Skill{Name: "review", Description: "review a pull request"}
+	registry.Register(deploy)
+	registry.Register(review)
+
+	embedder := &fakeEmbedder{vectors: map[string][]float32{
+		skillEmbeddingText(deploy): {1, 0},
+		skillEmbeddingText(review): {0, 1},
+		"ship it":                  {1, 0},
+	}}
+
+	if err := registry.EnableSemanticSearch(embedder, ""); err != nil {
+		t.Fatalf("EnableSemanticSearch returned unexpected error: %v", err)
+	}
+	registry.embedSkill(deploy)
+	registry.embedSkill(review)
+
+	matches, err := registry.SearchSemantic(context.Background(), "ship it", 10, 0)
+	if err != nil {
+		t.Fatalf("SearchSemantic returned unexpected error: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Fatal("expected at least one match")
+	}
+	if matches[0].Skill.Name != "deploy" {
+		t.Errorf("top match = %q, want %q", matches[0].Skill.Name, "deploy")
+	}
+	if matches[0].Score <= matches[len(matches)-1].Score && len(matches) > 1 {
+		t.Errorf("expected matches sorted by descending score, got %+v", matches)
+	}
+}
+
+func TestSearchSemanticFallsBackToSubstringSearchWithoutEmbedder(t *testing.T) {
+	registry := NewSkillRegistry()
+	registry.Register(&Skill{Name: "deploy", Description: "ship code to prod"})
+
+	matches, err := registry.SearchSemantic(context.Background(), "deploy", 10, 0)
+	if err != nil {
+		t.Fatalf("SearchSemantic returned unexpected error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Skill.Name != "deploy" {
+		t.Fatalf("expected substring fallback to find %q, got %+v", "deploy", matches)
+	}
+}
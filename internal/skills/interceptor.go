@@ -0,0 +1,69 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// InvokeHandler is the core invocation logic that an interceptor may call,
+// wrap, or short-circuit entirely. The final handler in any chain is always
+// (*SkillInvoker).invokeDirect.
+type InvokeHandler func(ctx context.Context, skillName, args string) (*InvokeResult, error)
+
+// Interceptor wraps skill invocation, analogous to a gRPC unary interceptor.
+// It receives the next handler in the chain and may run logic before and
+// after calling it, short-circuit without calling it, or rewrite the result
+// or error it returns.
+type Interceptor func(ctx context.Context, skillName, args string, next InvokeHandler) (*InvokeResult, error)
+
+// chainInterceptors composes a slice of interceptors with a terminal handler
+// into a single InvokeHandler, preserving order: the first interceptor in
+// the slice runs outermost.
+func chainInterceptors(interceptors []Interceptor, final InvokeHandler) InvokeHandler {
+	if len(interceptors) == 0 {
+		return final
+	}
+
+	next := chainInterceptors(interceptors[1:], final)
+	current := interceptors[0]
+
+	return func(ctx context.Context, skillName, args string) (*InvokeResult, error) {
+		return current(ctx, skillName, args, next)
+	}
+}
+
+// PanicError records a skill invocation that panicked instead of returning
+// normally, preserving enough detail (the skill name, the recovered value,
+// and a stack trace) for a caller to log it and degrade gracefully.
+type PanicError struct {
+	SkillName string
+	Value     interface{}
+	Stack     []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("skill %q panicked: %v", e.SkillName, e.Value)
+}
+
+// RecoveryInterceptor recovers from panics raised while building or invoking
+// a skill and converts them into a *PanicError, so malformed skill content
+// cannot crash the calling agent. It is registered by default on every
+// SkillInvoker.
+func RecoveryInterceptor() Interceptor {
+	return func(ctx context.Context, skillName, args string, next InvokeHandler) (result *InvokeResult, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = nil
+				err = &PanicError{
+					SkillName: skillName,
+					Value:     r,
+					Stack:     debug.Stack(),
+				}
+			}
+		}()
+
+		return next(ctx, skillName, args)
+	}
+}
@@ -0,0 +1,102 @@
+package skills
+
+import (
+	"context"
+	"testing"
+)
+
+func TestChainInterceptorsOrdersFirstInterceptorOutermost(t *testing.T) {
+	var order []string
+
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, skillName, args string, next InvokeHandler) (*InvokeResult, error) {
+			order = append(order, name+":before")
+			result, err := next(ctx, skillName, args)
+			order = append(order, name+":after")
+			return result, err
+		}
+	}
+
+	final := func(ctx context.Context, skillName, args string) (*InvokeResult, error) {
+		order = append(order, "final")
+		return &InvokeResult{}, nil
+	}
+
+	chain := chainInterceptors([]Interceptor{record("outer"), record("inner")}, final)
+	if _, err := chain(context.Background(), "test", ""); err != nil {
+		t.Fatalf("chain returned unexpected error: %v", err)
+	}
+
+	want := []string{"outer:before", "inner:before", "final", "inner:after", "outer:after"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestChainInterceptorsWithNoInterceptorsCallsFinalDirectly(t *testing.T) {
+	called := false
+	final := func(ctx context.Context, skillName, args string) (*InvokeResult, error) {
+		called = true
+		return &InvokeResult{}, nil
+	}
+
+	chain := chainInterceptors(nil, final)
+	if _, err := chain(context.Background(), "test", ""); err != nil {
+		t.Fatalf("chain returned unexpected error: %v", err)
+	}
+	if !called {
+		t.Fatal("expected the final handler to be called when there are no interceptors")
+	}
+}
+
+func TestRecoveryInterceptorConvertsPanicToPanicError(t *testing.T) {
+	recovered := RecoveryInterceptor()
+
+	panicking := func(ctx context.Context, skillName, args string) (*InvokeResult, error) {
+		panic("boom")
+	}
+
+	result, err := recovered(context.Background(), "flaky", "", panicking)
+	if result != nil {
+		t.Fatalf("expected a nil result after a panic, got %+v", result)
+	}
+	if err == nil {
+		t.Fatal("expected an error after a panic, got nil")
+	}
+
+	panicErr, ok := err.(*PanicError)
+	if !ok {
+		t.Fatalf("expected *PanicError, got %T", err)
+	}
+	if panicErr.SkillName != "flaky" {
+		t.Errorf("PanicError.SkillName = %q, want %q", panicErr.SkillName, "flaky")
+	}
+	if panicErr.Value != "boom" {
+		t.Errorf("PanicError.Value = %v, want %q", panicErr.Value, "boom")
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Error("expected a non-empty stack trace")
+	}
+}
+
+func TestRecoveryInterceptorPassesThroughNonPanickingResult(t *testing.T) {
+	recovered := RecoveryInterceptor()
+	want := &InvokeResult{}
+
+	next := func(ctx context.Context, skillName, args string) (*InvokeResult, error) {
+		return want, nil
+	}
+
+	result, err := recovered(context.Background(), "ok", "", next)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != want {
+		t.Fatalf("result = %+v, want %+v", result, want)
+	}
+}
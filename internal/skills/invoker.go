@@ -9,20 +9,55 @@ import (
 
 // SkillInvoker handles skill invocation.
 type SkillInvoker struct {
-	registry *SkillRegistry
-	loader   *SkillLoader
-	history  []SkillInvocation
+	registry     *SkillRegistry
+	loader       *SkillLoader
+	history      []SkillInvocation
+	interceptors []Interceptor
+	workingDir   string
 }
 
-// NewSkillInvoker creates a new skill invoker.
-func NewSkillInvoker(registry *SkillRegistry, loader *SkillLoader) *SkillInvoker {
-	return &SkillInvoker{
-		registry: registry,
-		loader:   loader,
-		history:  []SkillInvocation{},
+// InvokerOption configures a SkillInvoker at construction time.
+type InvokerOption func(*SkillInvoker)
+
+// WithInterceptors appends interceptors to the invoker's chain, in the order
+// given. They run after the default recovery interceptor but before
+// (*SkillInvoker).invokeDirect. This is the extension point for logging,
+// metrics, argument redaction, and per-skill authorization, without
+// modifying SkillInvoker itself.
+func WithInterceptors(interceptors ...Interceptor) InvokerOption {
+	return func(i *SkillInvoker) {
+		i.interceptors = append(i.interceptors, interceptors...)
 	}
 }
 
+// WithWorkingDir sets the directory SkillContext resolves "{{cwd}}",
+// "${cwd}", and "path"-typed inputs against. Defaults to "." (the process's
+// own working directory) if never set.
+func WithWorkingDir(dir string) InvokerOption {
+	return func(i *SkillInvoker) {
+		i.workingDir = dir
+	}
+}
+
+// NewSkillInvoker creates a new skill invoker. A recovery interceptor is
+// registered by default so a panicking skill cannot crash the calling agent;
+// additional interceptors can be layered on with WithInterceptors.
+func NewSkillInvoker(registry *SkillRegistry, loader *SkillLoader, opts ...InvokerOption) *SkillInvoker {
+	i := &SkillInvoker{
+		registry:     registry,
+		loader:       loader,
+		history:      []SkillInvocation{},
+		interceptors: []Interceptor{RecoveryInterceptor()},
+		workingDir:   ".",
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
 // InvokeResult represents the result of invoking a skill.
 type InvokeResult struct {
 	Skill       *Skill    `json:"skill"`
@@ -31,8 +66,16 @@ type InvokeResult struct {
 	CompletedAt time.Time `json:"completed_at"`
 }
 
-// Invoke invokes a skill by name with arguments.
+// Invoke invokes a skill by name with arguments, running it through the
+// invoker's interceptor chain.
 func (i *SkillInvoker) Invoke(ctx context.Context, skillName, args string) (*InvokeResult, error) {
+	handler := chainInterceptors(i.interceptors, i.invokeDirect)
+	return handler(ctx, skillName, args)
+}
+
+// invokeDirect resolves and runs a skill, bypassing the interceptor chain.
+// It is the terminal handler every interceptor chain ultimately calls.
+func (i *SkillInvoker) invokeDirect(ctx context.Context, skillName, args string) (*InvokeResult, error) {
 	startedAt := time.Now()
 
 	// Parse skill name (may include namespace like "namespace:skill")
@@ -50,10 +93,36 @@ func (i *SkillInvoker) Invoke(ctx context.Context, skillName, args string) (*Inv
 	}
 
 	// Create skill context
-	skillCtx := NewSkillContext(ctx, ".")
+	skillCtx := NewSkillContext(ctx, i.workingDir)
+
+	// Skills with a declared Inputs schema get their args validated,
+	// coerced, and defaulted before anything else sees them, and their
+	// "{{inputs.<name>}}" placeholders substituted via GetPrompt. A skill
+	// with no schema keeps the older behavior of exposing raw args as
+	// bare "{{key}}" variables.
+	promptSkill := skill
+	parsedArgs := parseArgsToMap(args)
+	if len(skill.Inputs) > 0 {
+		bound, err := skill.BindInputs(skillCtx.WorkingDir, parsedArgs)
+		if err != nil {
+			return nil, err
+		}
+		clone := *skill
+		clone.Content = skill.GetPrompt(bound)
+		promptSkill = &clone
+	} else {
+		for k, v := range parsedArgs {
+			skillCtx.Variables[k] = fmt.Sprintf("%v", v)
+		}
+	}
 
-	// Expand variables in content
-	expandedContent := skillCtx.ExpandVariables(skill.Content)
+	// Resolve include/include_code/use_skill directives, then expand
+	// variables in the result.
+	composed, err := i.ExpandDirectives(promptSkill, skillCtx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand skill directives: %w", err)
+	}
+	expandedContent := skillCtx.ExpandVariables(composed)
 
 	// Build prompt
 	prompt := buildSkillPrompt(skill, expandedContent, args)
@@ -91,6 +160,24 @@ func normalizeSkillName(name string) string {
 	return name
 }
 
+// parseArgsToMap parses a skill invocation's free-text args as whitespace-
+// separated "key=value" pairs - the same format (*LifecycleInvoker) already
+// renders bound inputs as for its audit log - so BindInputs can validate
+// and coerce them against the skill's declared Inputs schema. Tokens
+// without an "=" are ignored rather than rejected, since untyped skills
+// use this same args string as free-form text.
+func parseArgsToMap(args string) map[string]any {
+	result := make(map[string]any)
+	for _, token := range strings.Fields(args) {
+		key, value, ok := strings.Cut(token, "=")
+		if !ok {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
 // buildSkillPrompt builds the prompt for skill execution.
 func buildSkillPrompt(skill *Skill, content, args string) string {
 	var sb strings.Builder
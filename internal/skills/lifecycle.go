@@ -0,0 +1,283 @@
+package skills
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// InvocationEventType identifies the stage of a skill invocation an
+// InvocationEvent reports.
+type InvocationEventType string
+
+const (
+	EventStarted    InvocationEventType = "started"
+	EventPrompted   InvocationEventType = "prompted"
+	EventToolCall   InvocationEventType = "tool_call"
+	EventToolResult InvocationEventType = "tool_result"
+	EventOutput     InvocationEventType = "output"
+	EventFinished   InvocationEventType = "finished"
+	EventError      InvocationEventType = "error"
+)
+
+// InvocationEvent is one step in a skill invocation's lifecycle, published
+// on the channel (*LifecycleInvoker).Invoke returns.
+type InvocationEvent struct {
+	Type         InvocationEventType `json:"type"`
+	InvocationID string              `json:"invocation_id"`
+	SkillName    string              `json:"skill_name"`
+	At           time.Time           `json:"at"`
+	Prompt       string              `json:"prompt,omitempty"`
+	ToolName     string              `json:"tool_name,omitempty"`
+	ToolArgs     map[string]any      `json:"tool_args,omitempty"`
+	ToolResult   string              `json:"tool_result,omitempty"`
+	Output       string              `json:"output,omitempty"`
+	Err          error               `json:"-"`
+}
+
+// Invoker runs a skill and streams back its lifecycle as InvocationEvents,
+// as an alternative to SkillInvoker.Invoke's single-shot prompt/result for
+// callers that want to observe progress, enforce a per-skill timeout, or
+// audit the run.
+type Invoker interface {
+	Invoke(ctx context.Context, skill *Skill, inputs map[string]string) (<-chan InvocationEvent, error)
+}
+
+// AuditSink persists a completed SkillInvocation, e.g. to a JSONL file or a
+// database, for later inspection by a TUI or log viewer.
+type AuditSink interface {
+	Record(ctx context.Context, invocation SkillInvocation) error
+}
+
+// JSONLAuditSink is an AuditSink that appends each SkillInvocation as one
+// JSON object per line.
+type JSONLAuditSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewJSONLAuditSink creates a JSONLAuditSink writing to path.
+func NewJSONLAuditSink(path string) *JSONLAuditSink {
+	return &JSONLAuditSink{path: path}
+}
+
+// Record appends invocation to the sink's file as a single JSON line.
+func (s *JSONLAuditSink) Record(ctx context.Context, invocation SkillInvocation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(invocation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invocation: %w", err)
+	}
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LifecycleInvoker is the Invoker implementation that promotes
+// SkillInvocation into a full observable runtime on top of the same
+// registry/loader SkillInvoker uses: per-skill timeouts (from a skill's
+// `timeout:` frontmatter field), ctx cancellation, and an optional audit
+// trail with secret_inputs redaction.
+type LifecycleInvoker struct {
+	registry   *SkillRegistry
+	loader     *SkillLoader
+	audit      AuditSink
+	workingDir string
+}
+
+// NewLifecycleInvoker creates a LifecycleInvoker. audit may be nil to skip
+// auditing entirely. workingDir is resolved the same way SkillInvoker's is
+// (see WithWorkingDir); pass "." to use the process's own working directory.
+func NewLifecycleInvoker(registry *SkillRegistry, loader *SkillLoader, audit AuditSink, workingDir string) *LifecycleInvoker {
+	if workingDir == "" {
+		workingDir = "."
+	}
+	return &LifecycleInvoker{registry: registry, loader: loader, audit: audit, workingDir: workingDir}
+}
+
+// Invoke resolves directives and variables for skill and publishes its
+// lifecycle as InvocationEvents on the returned channel, which is closed
+// once the invocation finishes - by completing, erroring, being cancelled,
+// or exceeding the skill's timeout. The finished invocation is also handed
+// to the configured AuditSink, with any input named in the skill's
+// secret_inputs redacted first.
+func (li *LifecycleInvoker) Invoke(ctx context.Context, skill *Skill, inputs map[string]string) (<-chan InvocationEvent, error) {
+	if skill == nil {
+		return nil, fmt.Errorf("skill is nil")
+	}
+
+	events := make(chan InvocationEvent, 8)
+	id := ulid.Make().String()
+	invocation := SkillInvocation{
+		InvocationID: id,
+		SkillName:    skill.Name,
+		Args:         redactedInputsForAudit(inputs, skill.SecretInputs),
+		StartedAt:    time.Now(),
+	}
+
+	go func() {
+		defer close(events)
+
+		runCtx := ctx
+		if skill.Timeout > 0 {
+			var cancel context.CancelFunc
+			runCtx, cancel = context.WithTimeout(ctx, skill.Timeout)
+			defer cancel()
+		}
+
+		emit := func(evt InvocationEvent) {
+			evt.InvocationID = id
+			evt.SkillName = skill.Name
+			evt.At = time.Now()
+			select {
+			case events <- evt:
+			case <-runCtx.Done():
+			}
+		}
+
+		emit(InvocationEvent{Type: EventStarted})
+
+		skillCtx := NewSkillContext(runCtx, li.workingDir)
+
+		// A skill with a declared Inputs schema gets inputs validated,
+		// coerced, and defaulted via BindInputs, then its
+		// "{{inputs.<name>}}" placeholders substituted via GetPrompt -
+		// matching invokeDirect/Plan. A skill with no schema keeps the
+		// older behavior of exposing raw inputs as bare "{{key}}"
+		// variables.
+		promptSkill := skill
+		if len(skill.Inputs) > 0 {
+			argsAny := make(map[string]any, len(inputs))
+			for k, v := range inputs {
+				argsAny[k] = v
+			}
+			bound, err := skill.BindInputs(skillCtx.WorkingDir, argsAny)
+			if err != nil {
+				li.finish(ctx, events, id, skill.Name, &invocation, "", err)
+				return
+			}
+			clone := *skill
+			clone.Content = skill.GetPrompt(bound)
+			promptSkill = &clone
+		} else {
+			for k, v := range inputs {
+				skillCtx.Variables[k] = v
+			}
+		}
+
+		composed, err := li.expandDirectivesFor(promptSkill, skillCtx)
+		if err == nil && runCtx.Err() != nil {
+			err = runCtx.Err()
+		}
+		if err != nil {
+			li.finish(ctx, events, id, skill.Name, &invocation, "", err)
+			return
+		}
+
+		prompt := buildSkillPrompt(skill, skillCtx.ExpandVariables(composed), "")
+		emit(InvocationEvent{Type: EventPrompted, Prompt: prompt})
+
+		if err := runCtx.Err(); err != nil {
+			li.finish(ctx, events, id, skill.Name, &invocation, "", err)
+			return
+		}
+
+		emit(InvocationEvent{Type: EventOutput, Output: prompt})
+		li.finish(ctx, events, id, skill.Name, &invocation, prompt, nil)
+	}()
+
+	return events, nil
+}
+
+// expandDirectivesFor resolves include/use_skill directives using a
+// throwaway SkillInvoker bound to the same registry/loader, since
+// ExpandDirectives is defined there and LifecycleInvoker has no reason to
+// duplicate it.
+func (li *LifecycleInvoker) expandDirectivesFor(skill *Skill, sc *SkillContext) (string, error) {
+	inv := &SkillInvoker{registry: li.registry, loader: li.loader}
+	return inv.ExpandDirectives(skill, sc, "")
+}
+
+// finish emits the terminal Finished/Error event, fills in the invocation's
+// end-of-run fields, and records it to the audit sink. It always uses the
+// outer ctx (not a possibly-already-expired per-skill timeout context) so a
+// timed-out invocation can still be audited - and, critically, so the
+// terminal event's send isn't gated on the same runCtx.Done() that's often
+// exactly why finish is being called in the first place. Gating the
+// terminal send on runCtx too would race runCtx.Done() against the send
+// with no guaranteed winner, silently dropping the one event a timed-out
+// caller actually needs.
+func (li *LifecycleInvoker) finish(ctx context.Context, events chan<- InvocationEvent, id, skillName string, invocation *SkillInvocation, result string, err error) {
+	invocation.EndedAt = time.Now()
+	invocation.DurationMs = invocation.EndedAt.Sub(invocation.StartedAt).Milliseconds()
+
+	evt := InvocationEvent{InvocationID: id, SkillName: skillName, At: time.Now()}
+	if err != nil {
+		invocation.Error = err.Error()
+		evt.Type = EventError
+		evt.Err = err
+	} else {
+		invocation.Result = result
+		evt.Type = EventFinished
+		evt.Output = result
+	}
+
+	select {
+	case events <- evt:
+	case <-ctx.Done():
+	}
+
+	if li.audit != nil {
+		_ = li.audit.Record(ctx, *invocation)
+	}
+}
+
+// redactedInputsForAudit renders bound inputs as a stable "key=value" list
+// for SkillInvocation.Args, replacing the value of any key named in
+// secretInputs with a fixed placeholder rather than persisting it.
+func redactedInputsForAudit(inputs map[string]string, secretInputs []string) string {
+	if len(inputs) == 0 {
+		return ""
+	}
+
+	secret := make(map[string]bool, len(secretInputs))
+	for _, name := range secretInputs {
+		secret[name] = true
+	}
+
+	keys := make([]string, 0, len(inputs))
+	for k := range inputs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		value := inputs[k]
+		if secret[k] {
+			value = "***redacted***"
+		}
+		parts[i] = k + "=" + value
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,113 @@
+package skills
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newLifecycleInvoker(t *testing.T, audit AuditSink) *LifecycleInvoker {
+	t.Helper()
+	registry := NewSkillRegistry()
+	loader := NewSkillLoader(registry)
+	return NewLifecycleInvoker(registry, loader, audit, ".")
+}
+
+func drainEvents(t *testing.T, events <-chan InvocationEvent, timeout time.Duration) []InvocationEvent {
+	t.Helper()
+	var got []InvocationEvent
+	deadline := time.After(timeout)
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return got
+			}
+			got = append(got, evt)
+		case <-deadline:
+			t.Fatal("timed out waiting for events channel to close")
+		}
+	}
+}
+
+func TestLifecycleInvokerEmitsTerminalEventOnSuccess(t *testing.T) {
+	li := newLifecycleInvoker(t, nil)
+	skill := &Skill{Name: "greet", Content: "Hello {{name}}"}
+
+	events, err := li.Invoke(context.Background(), skill, map[string]string{"name": "world"})
+	if err != nil {
+		t.Fatalf("Invoke returned unexpected error: %v", err)
+	}
+
+	got := drainEvents(t, events, time.Second)
+	if len(got) == 0 {
+		t.Fatal("expected at least one event, got none")
+	}
+	last := got[len(got)-1]
+	if last.Type != EventFinished {
+		t.Fatalf("last event type = %q, want %q", last.Type, EventFinished)
+	}
+}
+
+// TestLifecycleInvokerAlwaysDeliversTerminalEventUnderTimeout is a
+// regression test: finish used to gate its terminal send on the per-skill
+// timeout context (runCtx), which is already Done() by the time a
+// timed-out invocation calls finish, so the terminal Finished/Error event
+// raced the channel close and could be silently dropped.
+func TestLifecycleInvokerAlwaysDeliversTerminalEventUnderTimeout(t *testing.T) {
+	li := newLifecycleInvoker(t, nil)
+
+	const runs = 50
+	for i := 0; i < runs; i++ {
+		skill := &Skill{Name: "slow", Content: "{{use_skill \"missing\"}}", Timeout: time.Nanosecond}
+
+		events, err := li.Invoke(context.Background(), skill, nil)
+		if err != nil {
+			t.Fatalf("run %d: Invoke returned unexpected error: %v", i, err)
+		}
+
+		got := drainEvents(t, events, time.Second)
+		if len(got) == 0 {
+			t.Fatalf("run %d: terminal event was dropped", i)
+		}
+		last := got[len(got)-1]
+		if last.Type != EventFinished && last.Type != EventError {
+			t.Fatalf("run %d: last event type = %q, want Finished or Error", i, last.Type)
+		}
+	}
+}
+
+func TestLifecycleInvokerRecordsAuditWithRedactedSecrets(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewJSONLAuditSink(filepath.Join(dir, "audit.jsonl"))
+	li := newLifecycleInvoker(t, sink)
+
+	skill := &Skill{Name: "login", Content: "user={{user}}", SecretInputs: []string{"token"}}
+
+	events, err := li.Invoke(context.Background(), skill, map[string]string{"user": "alice", "token": "super-secret"})
+	if err != nil {
+		t.Fatalf("Invoke returned unexpected error: %v", err)
+	}
+	drainEvents(t, events, time.Second)
+
+	data, err := os.ReadFile(filepath.Join(dir, "audit.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if got := string(data); !contains(got, "alice") || !contains(got, "***redacted***") || contains(got, "super-secret") {
+		t.Fatalf("audit log did not redact the secret input as expected: %s", got)
+	}
+}
+
+func contains(haystack, needle string) bool {
+	return len(haystack) >= len(needle) && (func() bool {
+		for i := 0; i+len(needle) <= len(haystack); i++ {
+			if haystack[i:i+len(needle)] == needle {
+				return true
+			}
+		}
+		return false
+	})()
+}
@@ -20,6 +20,7 @@ const (
 type SkillLoader struct {
 	sources  map[SkillSource]string
 	registry *SkillRegistry
+	events   chan SkillChangeEvent
 }
 
 // NewSkillLoader creates a new skill loader.
@@ -27,6 +28,7 @@ func NewSkillLoader(registry *SkillRegistry) *SkillLoader {
 	return &SkillLoader{
 		sources:  make(map[SkillSource]string),
 		registry: registry,
+		events:   make(chan SkillChangeEvent, 16),
 	}
 }
 
@@ -0,0 +1,193 @@
+package skills
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultPathArgKeys are the argument names CheckToolCall checks, in order,
+// for a bare path glob like "Read(**/*.go)" that doesn't name its arg
+// explicitly the way "Edit(path=src/**)" does.
+var defaultPathArgKeys = []string{"path", "file_path", "file"}
+
+// toolRule is a single parsed entry from AllowedTools or DeniedTools. It
+// covers four forms: a bare name ("Write"), the wildcard ("*"), a
+// negated allow-list entry ("!Write", equivalent to a deny rule), and a
+// call-style constraint ("Bash(git:*)", "Read(**/*.go)",
+// "Edit(path=src/**)").
+type toolRule struct {
+	raw     string
+	Name    string
+	Negated bool
+	Arg     string
+}
+
+var toolRulePattern = regexp.MustCompile(`^(!)?([A-Za-z_][A-Za-z0-9_]*|\*)(?:\(([^)]*)\))?$`)
+
+// parseToolRule parses one AllowedTools/DeniedTools entry. An entry that
+// doesn't match the expected grammar is treated as a bare tool name, so it
+// still participates (harmlessly) in matching rather than being dropped.
+func parseToolRule(raw string) toolRule {
+	trimmed := strings.TrimSpace(raw)
+	m := toolRulePattern.FindStringSubmatch(trimmed)
+	if m == nil {
+		return toolRule{raw: raw, Name: trimmed}
+	}
+	return toolRule{raw: raw, Negated: m[1] == "!", Name: m[2], Arg: m[3]}
+}
+
+// ToolCallError explains why (*Skill).CheckToolCall denied a tool call.
+type ToolCallError struct {
+	Tool        string
+	MatchedRule string // the AllowedTools/DeniedTools entry that triggered the denial, if any
+	Remediation string
+}
+
+// Error implements the error interface.
+func (e *ToolCallError) Error() string {
+	if e.MatchedRule == "" {
+		return fmt.Sprintf("tool %q is not permitted for this skill: %s", e.Tool, e.Remediation)
+	}
+	return fmt.Sprintf("tool %q denied by rule %q: %s", e.Tool, e.MatchedRule, e.Remediation)
+}
+
+// CheckToolCall decides whether a skill may call the named tool with the
+// given arguments, returning nil if the call is allowed or a *ToolCallError
+// describing why it isn't. Deny rules (DeniedTools, plus any "!Tool" entry
+// inside AllowedTools) always take precedence over allow rules. An empty
+// AllowedTools with a non-empty DeniedTools means "allow all except
+// denied"; an empty AllowedTools and empty DeniedTools means "allow all",
+// matching (*Skill).IsToolAllowed's existing behavior.
+func (s *Skill) CheckToolCall(name string, args map[string]any) error {
+	var denyRules, allowRules []toolRule
+
+	for _, raw := range s.DeniedTools {
+		denyRules = append(denyRules, parseToolRule(raw))
+	}
+	for _, raw := range s.AllowedTools {
+		rule := parseToolRule(raw)
+		if rule.Negated {
+			denyRules = append(denyRules, rule)
+		} else {
+			allowRules = append(allowRules, rule)
+		}
+	}
+
+	for _, rule := range denyRules {
+		if matchToolRule(rule, name, args) {
+			return &ToolCallError{
+				Tool:        name,
+				MatchedRule: rule.raw,
+				Remediation: fmt.Sprintf("remove or narrow the %q deny rule if %q should be allowed here", rule.raw, name),
+			}
+		}
+	}
+
+	if len(allowRules) == 0 {
+		return nil
+	}
+
+	for _, rule := range allowRules {
+		if matchToolRule(rule, name, args) {
+			return nil
+		}
+	}
+
+	return &ToolCallError{
+		Tool:        name,
+		Remediation: fmt.Sprintf("add %q (or a matching pattern) to this skill's allowed-tools", name),
+	}
+}
+
+// matchToolRule reports whether rule matches a call to name with args.
+func matchToolRule(rule toolRule, name string, args map[string]any) bool {
+	if rule.Name != "*" && !strings.EqualFold(rule.Name, name) {
+		return false
+	}
+	if rule.Name == "*" || rule.Arg == "" {
+		return true
+	}
+
+	if key, pattern, ok := strings.Cut(rule.Arg, "="); ok {
+		return matchArgGlob(args, strings.TrimSpace(key), strings.TrimSpace(pattern))
+	}
+
+	if strings.EqualFold(rule.Name, "bash") {
+		command, _ := args["command"].(string)
+		return matchCommandPattern(rule.Arg, command)
+	}
+
+	for _, key := range defaultPathArgKeys {
+		if _, ok := args[key]; ok {
+			return matchArgGlob(args, key, rule.Arg)
+		}
+	}
+	return false
+}
+
+// matchCommandPattern matches a "cmd:subcmd:*" rule against a Bash-style
+// command string, comparing whitespace-separated tokens positionally. A
+// "*" segment matches that position and everything after it.
+func matchCommandPattern(pattern, command string) bool {
+	patternParts := strings.Split(pattern, ":")
+	commandParts := strings.Fields(command)
+
+	for i, part := range patternParts {
+		if part == "*" {
+			return true
+		}
+		if i >= len(commandParts) || commandParts[i] != part {
+			return false
+		}
+	}
+	return len(commandParts) == len(patternParts)
+}
+
+// matchArgGlob matches args[key] (stringified) against a filepath.Match-style
+// glob, with "**" additionally supported as "match across path separators".
+func matchArgGlob(args map[string]any, key, pattern string) bool {
+	value, ok := args[key]
+	if !ok {
+		return false
+	}
+	matched, err := matchGlob(pattern, fmt.Sprintf("%v", value))
+	return err == nil && matched
+}
+
+// matchGlob is path/filepath.Match extended to treat "**" as "any sequence
+// of characters, including path separators" the way tools like rsync/glob
+// libraries do; filepath.Match alone has no such wildcard.
+func matchGlob(pattern, name string) (bool, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Match(pattern, name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '*' && i+1 < len(pattern) && pattern[i+1] == '*' && i+2 < len(pattern) && pattern[i+2] == '/':
+			// "**/" also matches zero path segments, so "**/*.go" matches "skill.go".
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case pattern[i] == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			sb.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+		case pattern[i] == '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(name), nil
+}
@@ -0,0 +1,166 @@
+package skills
+
+import "testing"
+
+func TestCheckToolCall(t *testing.T) {
+	tests := []struct {
+		name    string
+		skill   Skill
+		tool    string
+		args    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "no restrictions allows everything",
+			skill:   Skill{},
+			tool:    "Bash",
+			wantErr: false,
+		},
+		{
+			name:    "allow list permits a listed tool",
+			skill:   Skill{AllowedTools: []string{"Read", "Write"}},
+			tool:    "Write",
+			wantErr: false,
+		},
+		{
+			name:    "allow list rejects an unlisted tool",
+			skill:   Skill{AllowedTools: []string{"Read", "Write"}},
+			tool:    "Bash",
+			wantErr: true,
+		},
+		{
+			name:    "wildcard allow list permits everything",
+			skill:   Skill{AllowedTools: []string{"*"}},
+			tool:    "Bash",
+			wantErr: false,
+		},
+		{
+			name:    "negated entry inside AllowedTools denies that tool",
+			skill:   Skill{AllowedTools: []string{"*", "!Write"}},
+			tool:    "Write",
+			wantErr: true,
+		},
+		{
+			name:    "DeniedTools denies a tool even with no AllowedTools",
+			skill:   Skill{DeniedTools: []string{"Write"}},
+			tool:    "Write",
+			wantErr: true,
+		},
+		{
+			name:    "empty allow with non-empty deny allows everything else",
+			skill:   Skill{DeniedTools: []string{"Write"}},
+			tool:    "Read",
+			wantErr: false,
+		},
+		{
+			name:    "deny takes precedence over allow for the same tool",
+			skill:   Skill{AllowedTools: []string{"Write"}, DeniedTools: []string{"Write"}},
+			tool:    "Write",
+			wantErr: true,
+		},
+		{
+			name:    "Bash command-style allow matches a matching command",
+			skill:   Skill{AllowedTools: []string{"Bash(git:*)"}},
+			tool:    "Bash",
+			args:    map[string]any{"command": "git status"},
+			wantErr: false,
+		},
+		{
+			name:    "Bash command-style allow rejects a non-matching command",
+			skill:   Skill{AllowedTools: []string{"Bash(git:*)"}},
+			tool:    "Bash",
+			args:    map[string]any{"command": "rm -rf /"},
+			wantErr: true,
+		},
+		{
+			name:    "path glob allow matches a matching path arg",
+			skill:   Skill{AllowedTools: []string{"Read(**/*.go)"}},
+			tool:    "Read",
+			args:    map[string]any{"path": "internal/skills/skill.go"},
+			wantErr: false,
+		},
+		{
+			name:    "path glob allow rejects a non-matching path arg",
+			skill:   Skill{AllowedTools: []string{"Read(**/*.go)"}},
+			tool:    "Read",
+			args:    map[string]any{"path": "README.md"},
+			wantErr: true,
+		},
+		{
+			name:    "keyed arg glob deny blocks a matching path",
+			skill:   Skill{DeniedTools: []string{"Edit(path=secrets/**)"}},
+			tool:    "Edit",
+			args:    map[string]any{"path": "secrets/keys.env"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.skill.CheckToolCall(tt.tool, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckToolCall(%q, %v) error = %v, wantErr %v", tt.tool, tt.args, err, tt.wantErr)
+			}
+			if err != nil {
+				var toolErr *ToolCallError
+				if e, ok := err.(*ToolCallError); ok {
+					toolErr = e
+				}
+				if toolErr == nil {
+					t.Fatalf("expected a *ToolCallError, got %T", err)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchCommandPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		command string
+		want    bool
+	}{
+		{"git:*", "git status", true},
+		{"git:*", "git commit -m foo", true},
+		{"git:push:*", "git push origin main", true},
+		{"git:push:*", "git pull origin main", false},
+		{"git:*", "rm -rf /", false},
+		{"git", "git", true},
+		{"git", "git status", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.command, func(t *testing.T) {
+			if got := matchCommandPattern(tt.pattern, tt.command); got != tt.want {
+				t.Errorf("matchCommandPattern(%q, %q) = %v, want %v", tt.pattern, tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.go", "skill.go", true},
+		{"*.go", "nested/skill.go", false},
+		{"**/*.go", "internal/skills/skill.go", true},
+		{"**/*.go", "skill.go", true},
+		{"src/**", "src/a/b/c.ts", true},
+		{"src/**", "lib/a.ts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.name, func(t *testing.T) {
+			got, err := matchGlob(tt.pattern, tt.name)
+			if err != nil {
+				t.Fatalf("matchGlob(%q, %q) returned error: %v", tt.pattern, tt.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
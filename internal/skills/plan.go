@@ -0,0 +1,103 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+)
+
+// InvokePlan previews what Invoke would send to the model, without
+// committing to the invocation: nothing is appended to the invoker's
+// history and no AuditSink is notified.
+type InvokePlan struct {
+	Skill               *Skill   `json:"skill"`
+	ExpandedContent     string   `json:"expanded_content"`
+	UnresolvedVariables []string `json:"unresolved_variables,omitempty"`
+	Prompt              string   `json:"prompt"`
+	AllowedTools        []string `json:"allowed_tools,omitempty"`
+	Warnings            []string `json:"warnings,omitempty"`
+}
+
+// Plan resolves a skill and builds the exact prompt Invoke would send,
+// including fully expanded "{{var}}" placeholders, without recording an
+// invocation. This lets users inspect what a user- or project-contributed
+// skill will actually say to the model before running it for real.
+//
+// This is the library surface a `--dry-run` flag on whatever command
+// currently calls Invoke would use in place of it, but no such command, or
+// any cmd/ package / flag parsing, exists anywhere in this tree yet, so
+// that wiring is still open.
+func (i *SkillInvoker) Plan(ctx context.Context, skillName, args string) (*InvokePlan, error) {
+	skillName = normalizeSkillName(skillName)
+
+	skill := i.registry.Get(skillName)
+	if skill == nil {
+		var err error
+		skill, err = i.loader.LoadSkillByName(skillName)
+		if err != nil {
+			return nil, fmt.Errorf("skill not found: %s", skillName)
+		}
+	}
+
+	skillCtx := NewSkillContext(ctx, i.workingDir)
+
+	// Mirror invokeDirect: a declared Inputs schema gets validated/coerced
+	// and its "{{inputs.<name>}}" placeholders substituted via GetPrompt
+	// before directive expansion, so Plan previews exactly what Invoke
+	// would actually send. A skill with no schema keeps the older behavior
+	// of exposing raw args as bare "{{key}}" variables.
+	promptSkill := skill
+	parsedArgs := parseArgsToMap(args)
+	if len(skill.Inputs) > 0 {
+		bound, err := skill.BindInputs(skillCtx.WorkingDir, parsedArgs)
+		if err != nil {
+			return nil, err
+		}
+		clone := *skill
+		clone.Content = skill.GetPrompt(bound)
+		promptSkill = &clone
+	} else {
+		for k, v := range parsedArgs {
+			skillCtx.Variables[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	composed, err := i.ExpandDirectives(promptSkill, skillCtx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand skill directives: %w", err)
+	}
+	expandedContent := skillCtx.ExpandVariables(composed)
+	prompt := buildSkillPrompt(skill, expandedContent, args)
+
+	var unresolved []string
+	seenUnresolved := make(map[string]bool)
+	addUnresolved := func(name string) {
+		if seenUnresolved[name] {
+			return
+		}
+		seenUnresolved[name] = true
+		unresolved = append(unresolved, name)
+	}
+	for _, match := range variableRefPattern.FindAllStringSubmatch(expandedContent, -1) {
+		addUnresolved(match[1])
+	}
+	for _, match := range variableRefPatternBash.FindAllStringSubmatch(expandedContent, -1) {
+		addUnresolved(match[1])
+	}
+
+	var warnings []string
+	if skill.Path != "" {
+		entry := i.loader.diagnoseFile(SkillSource(skill.Source), skill.Path)
+		if entry.Severity != SeverityInfo {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+
+	return &InvokePlan{
+		Skill:               skill,
+		ExpandedContent:     expandedContent,
+		UnresolvedVariables: unresolved,
+		Prompt:              prompt,
+		AllowedTools:        skill.AllowedTools,
+		Warnings:            warnings,
+	}, nil
+}
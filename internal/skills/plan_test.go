@@ -0,0 +1,79 @@
+package skills
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPlanBuildsPromptWithoutRecordingHistory(t *testing.T) {
+	registry := NewSkillRegistry()
+	registry.Register(&Skill{Name: "greet", Content: "Hello {{name}}"})
+	invoker := NewSkillInvoker(registry, NewSkillLoader(registry))
+
+	plan, err := invoker.Plan(context.Background(), "greet", "name=world")
+	if err != nil {
+		t.Fatalf("Plan returned unexpected error: %v", err)
+	}
+	if plan.Skill.Name != "greet" {
+		t.Errorf("Skill.Name = %q, want %q", plan.Skill.Name, "greet")
+	}
+	if got, want := plan.Prompt, "Hello world"; !containsSubstring(got, want) {
+		t.Errorf("Prompt = %q, want it to contain %q", got, want)
+	}
+	if len(invoker.history) != 0 {
+		t.Errorf("expected Plan not to record any history, got %d entries", len(invoker.history))
+	}
+}
+
+func TestPlanReportsUnresolvedVariables(t *testing.T) {
+	registry := NewSkillRegistry()
+	registry.Register(&Skill{Name: "greet", Content: "Hello {{missing}}"})
+	invoker := NewSkillInvoker(registry, NewSkillLoader(registry))
+
+	plan, err := invoker.Plan(context.Background(), "greet", "")
+	if err != nil {
+		t.Fatalf("Plan returned unexpected error: %v", err)
+	}
+	if len(plan.UnresolvedVariables) != 1 || plan.UnresolvedVariables[0] != "missing" {
+		t.Fatalf("UnresolvedVariables = %v, want [missing]", plan.UnresolvedVariables)
+	}
+}
+
+func TestPlanReportsUnresolvedBashStyleVariables(t *testing.T) {
+	registry := NewSkillRegistry()
+	registry.Register(&Skill{Name: "greet", Content: "Hello ${missing_var}"})
+	invoker := NewSkillInvoker(registry, NewSkillLoader(registry))
+
+	plan, err := invoker.Plan(context.Background(), "greet", "")
+	if err != nil {
+		t.Fatalf("Plan returned unexpected error: %v", err)
+	}
+	if len(plan.UnresolvedVariables) != 1 || plan.UnresolvedVariables[0] != "missing_var" {
+		t.Fatalf("UnresolvedVariables = %v, want [missing_var]", plan.UnresolvedVariables)
+	}
+}
+
+func TestPlanReturnsErrorForUnknownSkill(t *testing.T) {
+	registry := NewSkillRegistry()
+	invoker := NewSkillInvoker(registry, NewSkillLoader(registry))
+
+	if _, err := invoker.Plan(context.Background(), "does-not-exist", ""); err == nil {
+		t.Fatal("expected an error for an unknown skill, got nil")
+	}
+}
+
+func TestPlanBindsDeclaredInputs(t *testing.T) {
+	registry := NewSkillRegistry()
+	skill := newSkillWithInputs([]SkillInputSpec{{Name: "mode", Type: "enum", Enum: []string{"fast", "slow"}, Default: "fast"}})
+	skill.Content = "mode={{inputs.mode}}"
+	registry.Register(skill)
+	invoker := NewSkillInvoker(registry, NewSkillLoader(registry))
+
+	plan, err := invoker.Plan(context.Background(), skill.Name, "")
+	if err != nil {
+		t.Fatalf("Plan returned unexpected error: %v", err)
+	}
+	if got, want := plan.Prompt, "mode=fast"; !containsSubstring(got, want) {
+		t.Errorf("Prompt = %q, want it to contain %q", got, want)
+	}
+}
@@ -10,6 +10,14 @@ import (
 type SkillRegistry struct {
 	mu     sync.RWMutex
 	skills map[string]*Skill
+
+	// Optional embedding-backed semantic search, configured via
+	// EnableSemanticSearch. Left zero-valued, SearchSemantic simply falls
+	// back to the substring Search below.
+	vectorsMu sync.RWMutex
+	embedder  Embedder
+	indexPath string
+	vectors   map[string]vectorCacheEntry
 }
 
 // NewSkillRegistry creates a new skill registry.
@@ -28,6 +36,13 @@ func (r *SkillRegistry) Register(skill *Skill) {
 	// Normalize name to lowercase
 	name := strings.ToLower(skill.Name)
 	r.skills[name] = skill
+
+	r.vectorsMu.RLock()
+	hasEmbedder := r.embedder != nil
+	r.vectorsMu.RUnlock()
+	if hasEmbedder {
+		go r.embedSkill(skill)
+	}
 }
 
 // Get retrieves a skill by name.
@@ -2,57 +2,185 @@
 package skills
 
 import (
-	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Skill represents a specialized capability that can be invoked.
 type Skill struct {
-	Name          string   `json:"name" yaml:"name"`
-	Description   string   `json:"description" yaml:"description"`
-	AllowedTools  []string `json:"allowed_tools,omitempty" yaml:"allowed-tools,omitempty"`
-	Content       string   `json:"content" yaml:"content"`
-	Source        string   `json:"source,omitempty"` // local, user, project
-	Path          string   `json:"path,omitempty"`
-	LastModified  time.Time `json:"last_modified,omitempty"`
+	Name         string           `json:"name" yaml:"name"`
+	Description  string           `json:"description" yaml:"description"`
+	AllowedTools []string         `json:"allowed_tools,omitempty" yaml:"allowed-tools,omitempty"`
+	DeniedTools  []string         `json:"denied_tools,omitempty" yaml:"denied-tools,omitempty"`
+	Model        string           `json:"model,omitempty" yaml:"model,omitempty"`
+	Tags         []string         `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Inputs       []SkillInputSpec `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	Timeout      time.Duration    `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	SecretInputs []string         `json:"secret_inputs,omitempty" yaml:"secret-inputs,omitempty"`
+	Content      string           `json:"content" yaml:"content"`
+	Source       string           `json:"source,omitempty"` // local, user, project
+	Path         string           `json:"path,omitempty"`
+	LastModified time.Time        `json:"last_modified,omitempty"`
+
+	// compiledPatterns caches each input's Pattern regex, compiled once at
+	// parse time so BindInputs never recompiles it per call.
+	compiledPatterns map[string]*regexp.Regexp
+}
+
+// SkillToolsMetadata is the nested `tools:` frontmatter block, an
+// alternative to the flat `allowed-tools:` list for skills that also want
+// to declare denials (consumed by the tool-permission model in CheckToolCall).
+type SkillToolsMetadata struct {
+	Allowed []string `yaml:"allowed,omitempty"`
+	Denied  []string `yaml:"denied,omitempty"`
+}
+
+// SkillInputSpec declares one parameter a skill accepts, consumed by
+// (*Skill).BindInputs once a skill opts into typed inputs. Type is one of
+// "string" (the default), "int", "bool", "path", or "enum".
+type SkillInputSpec struct {
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type"`
+	Required    bool     `yaml:"required,omitempty"`
+	Default     string   `yaml:"default,omitempty"`
+	Pattern     string   `yaml:"pattern,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Enum        []string `yaml:"enum,omitempty"`
+}
+
+// InputError reports every SkillInputSpec field that failed BindInputs
+// validation, rather than stopping at the first one.
+type InputError struct {
+	Fields map[string]string
+}
+
+// Error implements the error interface.
+func (e *InputError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s: %s", name, e.Fields[name])
+	}
+
+	return "invalid skill inputs: " + strings.Join(parts, "; ")
 }
 
 // SkillMetadata contains metadata extracted from skill frontmatter.
 type SkillMetadata struct {
-	Name         string   `yaml:"name"`
-	Description  string   `yaml:"description"`
-	AllowedTools []string `yaml:"allowed-tools"`
+	Name         string             `yaml:"name"`
+	Description  string             `yaml:"description"`
+	AllowedTools []string           `yaml:"allowed-tools"`
+	Tools        SkillToolsMetadata `yaml:"tools"`
+	Model        string             `yaml:"model"`
+	Tags         []string           `yaml:"tags"`
+	Inputs       []SkillInputSpec   `yaml:"inputs"`
+	// Timeout bounds a single invocation (e.g. "30s", "2m"); see
+	// (*LifecycleInvoker).Invoke.
+	Timeout string `yaml:"timeout"`
+	// SecretInputs lists input names an AuditSink must redact before
+	// persisting a SkillInvocation.
+	SecretInputs []string `yaml:"secret_inputs"`
 }
 
-// ParseSkill parses a skill from markdown content with YAML frontmatter.
+// ParseSkill parses a skill from markdown content with YAML frontmatter. It
+// parses frontmatter with a real YAML unmarshal first, falling back to the
+// old permissive line-by-line parser for hand-edited files that YAML
+// rejects (e.g. unquoted strings containing a bare colon). Use
+// ParseSkillStrict to require well-formed YAML instead of falling back.
 func ParseSkill(content, source, path string) (*Skill, error) {
-	// Extract frontmatter
 	frontmatter, body, err := extractFrontmatter(content)
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse frontmatter
-	meta, err := parseFrontmatter(frontmatter)
+	meta, err := parseFrontmatterYAML(frontmatter)
+	if err != nil {
+		meta, err = parseFrontmatterPermissive(frontmatter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+		}
+	}
+
+	return skillFromMetadata(meta, body, source, path)
+}
+
+// ParseSkillStrict parses a skill like ParseSkill, but requires frontmatter
+// to be well-formed YAML rather than silently falling back to the
+// permissive parser. Use this to validate a skill before shipping it.
+func ParseSkillStrict(content, source, path string) (*Skill, error) {
+	frontmatter, body, err := extractFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := parseFrontmatterYAML(frontmatter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
 	}
 
+	return skillFromMetadata(meta, body, source, path)
+}
+
+// skillFromMetadata builds a Skill from parsed frontmatter metadata and the
+// markdown body following it.
+func skillFromMetadata(meta *SkillMetadata, body, source, path string) (*Skill, error) {
 	if meta.Name == "" {
 		return nil, fmt.Errorf("skill name is required")
 	}
 
+	allowedTools := meta.AllowedTools
+	if len(meta.Tools.Allowed) > 0 {
+		allowedTools = append(append([]string{}, allowedTools...), meta.Tools.Allowed...)
+	}
+
+	compiled := make(map[string]*regexp.Regexp, len(meta.Inputs))
+	for _, input := range meta.Inputs {
+		if input.Pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(input.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("input %q: invalid pattern %q: %w", input.Name, input.Pattern, err)
+		}
+		compiled[input.Name] = re
+	}
+
+	var timeout time.Duration
+	if meta.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(meta.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", meta.Timeout, err)
+		}
+	}
+
 	return &Skill{
-		Name:         meta.Name,
-		Description:  meta.Description,
-		AllowedTools: meta.AllowedTools,
-		Content:      body,
-		Source:       source,
-		Path:         path,
-		LastModified: time.Now(),
+		Name:             meta.Name,
+		Description:      meta.Description,
+		AllowedTools:     allowedTools,
+		DeniedTools:      meta.Tools.Denied,
+		Model:            meta.Model,
+		Tags:             meta.Tags,
+		Inputs:           meta.Inputs,
+		Timeout:          timeout,
+		SecretInputs:     meta.SecretInputs,
+		Content:          body,
+		Source:           source,
+		Path:             path,
+		LastModified:     time.Now(),
+		compiledPatterns: compiled,
 	}, nil
 }
 
@@ -69,8 +197,21 @@ func extractFrontmatter(content string) (string, string, error) {
 	return matches[1], matches[2], nil
 }
 
-// parseFrontmatter parses YAML frontmatter into metadata.
-func parseFrontmatter(frontmatter string) (*SkillMetadata, error) {
+// parseFrontmatterYAML parses frontmatter as real YAML, supporting
+// multi-line lists, quoted strings containing colons, block scalars, and
+// the nested `tools`/`inputs` structures a flat line-by-line split can't.
+func parseFrontmatterYAML(frontmatter string) (*SkillMetadata, error) {
+	meta := &SkillMetadata{}
+	if err := yaml.Unmarshal([]byte(frontmatter), meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// parseFrontmatterPermissive is the original line-by-line frontmatter
+// parser, kept as a fallback for malformed files that aren't valid YAML. It
+// only understands the flat `name`/`description`/`allowed-tools` fields.
+func parseFrontmatterPermissive(frontmatter string) (*SkillMetadata, error) {
 	meta := &SkillMetadata{}
 
 	lines := strings.Split(frontmatter, "\n")
@@ -108,85 +249,146 @@ func parseFrontmatter(frontmatter string) (*SkillMetadata, error) {
 	return meta, nil
 }
 
-// GetPrompt returns the skill content as a prompt.
-func (s *Skill) GetPrompt(args string) string {
+// GetPrompt returns the skill content as a prompt, substituting each
+// "{{inputs.<name>}}" placeholder with its bound value. Pass the map
+// returned by BindInputs.
+func (s *Skill) GetPrompt(inputs map[string]string) string {
 	prompt := s.Content
 
-	// Replace {{args}} placeholder if present
-	if strings.Contains(prompt, "{{args}}") {
-		prompt = strings.ReplaceAll(prompt, "{{args}}", args)
-	} else if args != "" {
-		// Append args if no placeholder
-		prompt = prompt + "\n\n## Arguments\n\n" + args
+	for name, value := range inputs {
+		prompt = strings.ReplaceAll(prompt, "{{inputs."+name+"}}", value)
 	}
 
 	return prompt
 }
 
-// IsToolAllowed checks if a tool is allowed for this skill.
-func (s *Skill) IsToolAllowed(toolName string) bool {
-	// If no restrictions, all tools allowed
-	if len(s.AllowedTools) == 0 {
-		return true
-	}
+// BindInputs validates and coerces args against the skill's declared Inputs
+// schema, applying defaults for anything missing and returning a map of
+// name to string value suitable for GetPrompt/ExpandVariables. "path"-typed
+// inputs resolve relative to workingDir (typically SkillContext.WorkingDir),
+// not the process's own working directory. It collects every failing field
+// into a single *InputError instead of stopping at the first one. A skill
+// with no declared Inputs returns an empty map.
+func (s *Skill) BindInputs(workingDir string, args map[string]any) (map[string]string, error) {
+	bound := make(map[string]string, len(s.Inputs))
+	fieldErrs := make(map[string]string)
 
-	// Check for wildcard
-	for _, t := range s.AllowedTools {
-		if t == "*" {
-			return true
+	for _, spec := range s.Inputs {
+		raw, provided := args[spec.Name]
+		if !provided || raw == nil {
+			if spec.Required && spec.Default == "" {
+				fieldErrs[spec.Name] = "required input not provided"
+				continue
+			}
+			raw = spec.Default
 		}
-	}
 
-	// Check specific tool
-	for _, t := range s.AllowedTools {
-		if strings.EqualFold(t, toolName) {
-			return true
+		value := fmt.Sprintf("%v", raw)
+
+		// An optional field that ended up with no value at all (not
+		// provided, and no default) has nothing to coerce or
+		// pattern-match against - e.g. strconv.Atoi("") on an unset "int"
+		// input would fail even though the field was never required.
+		if value == "" && !spec.Required {
+			bound[spec.Name] = ""
+			continue
 		}
+
+		switch spec.Type {
+		case "", "string":
+			// no coercion needed
+		case "int":
+			if _, err := strconv.Atoi(value); err != nil {
+				fieldErrs[spec.Name] = fmt.Sprintf("must be an int: %v", err)
+				continue
+			}
+		case "bool":
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				fieldErrs[spec.Name] = fmt.Sprintf("must be a bool: %v", err)
+				continue
+			}
+			value = strconv.FormatBool(parsed)
+		case "path":
+			value = expandInputPath(workingDir, value)
+		case "enum":
+			if !containsString(spec.Enum, value) {
+				fieldErrs[spec.Name] = fmt.Sprintf("must be one of %v", spec.Enum)
+				continue
+			}
+		default:
+			fieldErrs[spec.Name] = fmt.Sprintf("unknown input type %q", spec.Type)
+			continue
+		}
+
+		if re, ok := s.compiledPatterns[spec.Name]; ok && !re.MatchString(value) {
+			fieldErrs[spec.Name] = fmt.Sprintf("does not match pattern %q", spec.Pattern)
+			continue
+		}
+
+		bound[spec.Name] = value
 	}
 
-	return false
-}
+	if len(fieldErrs) > 0 {
+		return nil, &InputError{Fields: fieldErrs}
+	}
 
-// SkillInvocation represents an invocation of a skill.
-type SkillInvocation struct {
-	SkillName string    `json:"skill_name"`
-	Args      string    `json:"args,omitempty"`
-	StartedAt time.Time `json:"started_at"`
-	Result    string    `json:"result,omitempty"`
-	Error     string    `json:"error,omitempty"`
+	return bound, nil
 }
 
-// SkillContext provides context for skill execution.
-type SkillContext struct {
-	Ctx           context.Context
-	WorkingDir    string
-	ProjectRoot   string
-	CurrentFile   string
-	SelectionText string
-	Variables     map[string]string
-}
+// expandInputPath expands a leading "~" to the user's home directory, then
+// resolves the result to an absolute path against workingDir rather than
+// the process's own working directory (which may well be a different
+// directory than the skill is actually running against).
+func expandInputPath(workingDir, value string) string {
+	if value == "~" || strings.HasPrefix(value, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			value = filepath.Join(home, strings.TrimPrefix(value, "~"))
+		}
+	}
 
-// NewSkillContext creates a new skill context.
-func NewSkillContext(ctx context.Context, workingDir string) *SkillContext {
-	return &SkillContext{
-		Ctx:        ctx,
-		WorkingDir: workingDir,
-		Variables:  make(map[string]string),
+	if filepath.IsAbs(value) {
+		return filepath.Clean(value)
 	}
-}
 
-// ExpandVariables expands variables in the skill content.
-func (sc *SkillContext) ExpandVariables(content string) string {
-	// Built-in variables
-	content = strings.ReplaceAll(content, "{{cwd}}", sc.WorkingDir)
-	content = strings.ReplaceAll(content, "{{project_root}}", sc.ProjectRoot)
-	content = strings.ReplaceAll(content, "{{current_file}}", sc.CurrentFile)
-	content = strings.ReplaceAll(content, "{{selection}}", sc.SelectionText)
+	if workingDir == "" {
+		workingDir = "."
+	}
+	if abs, err := filepath.Abs(filepath.Join(workingDir, value)); err == nil {
+		return abs
+	}
+	return value
+}
 
-	// Custom variables
-	for k, v := range sc.Variables {
-		content = strings.ReplaceAll(content, "{{" + k + "}}", v)
+// containsString reports whether values contains s.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
 	}
+	return false
+}
+
+// IsToolAllowed checks if a tool is allowed for this skill. It delegates to
+// CheckToolCall (with no call-site arguments) so a skill that only sets
+// DeniedTools/denied-tools is rejected consistently by both methods,
+// instead of IsToolAllowed always returning true for it.
+func (s *Skill) IsToolAllowed(toolName string) bool {
+	return s.CheckToolCall(toolName, nil) == nil
+}
 
-	return content
+// SkillInvocation represents an invocation of a skill.
+type SkillInvocation struct {
+	InvocationID string    `json:"invocation_id,omitempty"`
+	SkillName    string    `json:"skill_name"`
+	Args         string    `json:"args,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	EndedAt      time.Time `json:"ended_at,omitempty"`
+	DurationMs   int64     `json:"duration_ms,omitempty"`
+	TokensIn     int       `json:"tokens_in,omitempty"`
+	TokensOut    int       `json:"tokens_out,omitempty"`
+	Result       string    `json:"result,omitempty"`
+	Error        string    `json:"error,omitempty"`
 }
+
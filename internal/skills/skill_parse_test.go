@@ -0,0 +1,90 @@
+package skills
+
+import "testing"
+
+func TestParseSkillRealYAML(t *testing.T) {
+	content := "---\n" +
+		"name: deploy\n" +
+		"description: ship code to prod\n" +
+		"allowed-tools:\n  - Read\n  - Bash(git:*)\n" +
+		"timeout: 30s\n" +
+		"---\n" +
+		"Deploy the app.\n"
+
+	skill, err := ParseSkill(content, "local", "deploy.md")
+	if err != nil {
+		t.Fatalf("ParseSkill returned unexpected error: %v", err)
+	}
+	if skill.Name != "deploy" {
+		t.Errorf("Name = %q, want %q", skill.Name, "deploy")
+	}
+	if len(skill.AllowedTools) != 2 || skill.AllowedTools[1] != "Bash(git:*)" {
+		t.Errorf("AllowedTools = %v, want [Read Bash(git:*)]", skill.AllowedTools)
+	}
+	if skill.Timeout.Seconds() != 30 {
+		t.Errorf("Timeout = %v, want 30s", skill.Timeout)
+	}
+}
+
+func TestParseSkillFallsBackToPermissiveForMalformedYAML(t *testing.T) {
+	// An unquoted value containing a bare colon is not valid YAML, so this
+	// must fall back to parseFrontmatterPermissive rather than erroring.
+	content := "---\nname: deploy\ndescription: ships to: prod\n---\nbody\n"
+
+	skill, err := ParseSkill(content, "local", "deploy.md")
+	if err != nil {
+		t.Fatalf("ParseSkill returned unexpected error for malformed YAML: %v", err)
+	}
+	if skill.Name != "deploy" {
+		t.Errorf("Name = %q, want %q", skill.Name, "deploy")
+	}
+}
+
+func TestParseSkillStrictRejectsMalformedYAML(t *testing.T) {
+	content := "---\nname: deploy\ndescription: ships to: prod\n---\nbody\n"
+
+	if _, err := ParseSkillStrict(content, "local", "deploy.md"); err == nil {
+		t.Fatal("expected ParseSkillStrict to reject malformed YAML, got nil error")
+	}
+}
+
+func TestParseSkillStrictAcceptsWellFormedYAML(t *testing.T) {
+	content := "---\nname: deploy\ndescription: ship code to prod\n---\nbody\n"
+
+	skill, err := ParseSkillStrict(content, "local", "deploy.md")
+	if err != nil {
+		t.Fatalf("ParseSkillStrict returned unexpected error: %v", err)
+	}
+	if skill.Name != "deploy" {
+		t.Errorf("Name = %q, want %q", skill.Name, "deploy")
+	}
+}
+
+func TestParseSkillRequiresName(t *testing.T) {
+	content := "---\ndescription: no name here\n---\nbody\n"
+
+	if _, err := ParseSkill(content, "local", "deploy.md"); err == nil {
+		t.Fatal("expected an error for frontmatter missing a name, got nil")
+	}
+}
+
+func TestParseSkillMergesToolsAllowedIntoAllowedTools(t *testing.T) {
+	content := "---\n" +
+		"name: deploy\n" +
+		"description: ship code to prod\n" +
+		"allowed-tools: [Read]\n" +
+		"tools:\n  allowed: [Write]\n  denied: [Bash]\n" +
+		"---\n" +
+		"body\n"
+
+	skill, err := ParseSkill(content, "local", "deploy.md")
+	if err != nil {
+		t.Fatalf("ParseSkill returned unexpected error: %v", err)
+	}
+	if len(skill.AllowedTools) != 2 || skill.AllowedTools[0] != "Read" || skill.AllowedTools[1] != "Write" {
+		t.Errorf("AllowedTools = %v, want [Read Write]", skill.AllowedTools)
+	}
+	if len(skill.DeniedTools) != 1 || skill.DeniedTools[0] != "Bash" {
+		t.Errorf("DeniedTools = %v, want [Bash]", skill.DeniedTools)
+	}
+}
@@ -0,0 +1,106 @@
+package skills
+
+import "testing"
+
+func newSkillWithInputs(inputs []SkillInputSpec) *Skill {
+	skill, err := skillFromMetadata(&SkillMetadata{Name: "test", Inputs: inputs}, "content", "local", "")
+	if err != nil {
+		panic(err)
+	}
+	return skill
+}
+
+func TestBindInputsRequiredMissing(t *testing.T) {
+	skill := newSkillWithInputs([]SkillInputSpec{{Name: "target", Type: "string", Required: true}})
+
+	_, err := skill.BindInputs(".", map[string]any{})
+	if err == nil {
+		t.Fatal("expected an error for a missing required input, got nil")
+	}
+	if _, ok := err.(*InputError); !ok {
+		t.Fatalf("expected *InputError, got %T", err)
+	}
+}
+
+func TestBindInputsOptionalWithNoDefaultIsSkippedNotCoerced(t *testing.T) {
+	tests := []struct {
+		name string
+		spec SkillInputSpec
+	}{
+		{name: "bool", spec: SkillInputSpec{Name: "verbose", Type: "bool", Required: false}},
+		{name: "int", spec: SkillInputSpec{Name: "count", Type: "int", Required: false}},
+		{name: "enum", spec: SkillInputSpec{Name: "mode", Type: "enum", Required: false, Enum: []string{"a", "b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skill := newSkillWithInputs([]SkillInputSpec{tt.spec})
+
+			bound, err := skill.BindInputs(".", map[string]any{})
+			if err != nil {
+				t.Fatalf("BindInputs returned unexpected error for an omitted optional %s input: %v", tt.spec.Type, err)
+			}
+			if got := bound[tt.spec.Name]; got != "" {
+				t.Errorf("bound[%q] = %q, want empty string", tt.spec.Name, got)
+			}
+		})
+	}
+}
+
+func TestBindInputsCoercion(t *testing.T) {
+	skill := newSkillWithInputs([]SkillInputSpec{
+		{Name: "count", Type: "int", Required: true},
+		{Name: "verbose", Type: "bool", Default: "false"},
+		{Name: "mode", Type: "enum", Enum: []string{"fast", "slow"}, Default: "fast"},
+	})
+
+	bound, err := skill.BindInputs(".", map[string]any{"count": "3", "verbose": "true"})
+	if err != nil {
+		t.Fatalf("BindInputs returned unexpected error: %v", err)
+	}
+	if bound["count"] != "3" {
+		t.Errorf("count = %q, want %q", bound["count"], "3")
+	}
+	if bound["verbose"] != "true" {
+		t.Errorf("verbose = %q, want %q", bound["verbose"], "true")
+	}
+	if bound["mode"] != "fast" {
+		t.Errorf("mode = %q, want default %q", bound["mode"], "fast")
+	}
+}
+
+func TestBindInputsEnumRejectsUnlistedValue(t *testing.T) {
+	skill := newSkillWithInputs([]SkillInputSpec{
+		{Name: "mode", Type: "enum", Enum: []string{"fast", "slow"}},
+	})
+
+	_, err := skill.BindInputs(".", map[string]any{"mode": "turbo"})
+	if err == nil {
+		t.Fatal("expected an error for an enum value outside the declared set, got nil")
+	}
+}
+
+func TestBindInputsPathResolvesAgainstWorkingDir(t *testing.T) {
+	skill := newSkillWithInputs([]SkillInputSpec{
+		{Name: "target", Type: "path"},
+	})
+
+	bound, err := skill.BindInputs("/project/root", map[string]any{"target": "src/main.go"})
+	if err != nil {
+		t.Fatalf("BindInputs returned unexpected error: %v", err)
+	}
+	want := "/project/root/src/main.go"
+	if bound["target"] != want {
+		t.Errorf("target = %q, want %q", bound["target"], want)
+	}
+}
+
+func TestGetPromptSubstitutesBoundInputs(t *testing.T) {
+	skill := &Skill{Content: "Hello {{inputs.name}}, mode={{inputs.mode}}"}
+
+	got := skill.GetPrompt(map[string]string{"name": "world", "mode": "fast"})
+	want := "Hello world, mode=fast"
+	if got != want {
+		t.Errorf("GetPrompt() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,175 @@
+package skills
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// SkillContext provides context for skill execution.
+type SkillContext struct {
+	Ctx           context.Context
+	WorkingDir    string
+	ProjectRoot   string
+	CurrentFile   string
+	SelectionText string
+	Variables     map[string]string
+	// Files maps a logical name to file content, available to the
+	// {{if}}/{{range}}/{{with}} template pass as {{.Files.<name>}}.
+	Files map[string]string
+}
+
+// NewSkillContext creates a new skill context.
+func NewSkillContext(ctx context.Context, workingDir string) *SkillContext {
+	return &SkillContext{
+		Ctx:        ctx,
+		WorkingDir: workingDir,
+		Variables:  make(map[string]string),
+		Files:      make(map[string]string),
+	}
+}
+
+// substitutor implements one placeholder syntax variant for a single
+// key/value pair (e.g. "{{key}}", "${key}", "${key:-default}"). New
+// syntaxes are added to variableSubstitutors below.
+type substitutor func(in, key, value string) string
+
+// variableSubstitutors is the ordered pipeline ExpandVariables runs over
+// every built-in and custom variable. Order matters where syntaxes could
+// overlap (e.g. "${key}" must not be re-touched by a later "${key/../..}"
+// substitutor once it has already been replaced with a literal value).
+var variableSubstitutors = []substitutor{
+	substituteBraceLiteral,
+	substituteDollarBraceReplace,
+	substituteDollarBraceDefault,
+	substituteDollarBraceAlt,
+	substituteDollarBrace,
+}
+
+// substituteBraceLiteral replaces the legacy "{{key}}" placeholder with
+// value.
+func substituteBraceLiteral(in, key, value string) string {
+	return strings.ReplaceAll(in, "{{"+key+"}}", value)
+}
+
+// substituteDollarBrace replaces "${key}" with value.
+func substituteDollarBrace(in, key, value string) string {
+	return strings.ReplaceAll(in, "${"+key+"}", value)
+}
+
+// substituteDollarBraceDefault replaces "${key:-default}" with value if
+// value is non-empty, or with "default" otherwise.
+func substituteDollarBraceDefault(in, key, value string) string {
+	re := regexp.MustCompile(`\$\{` + regexp.QuoteMeta(key) + `:-([^}]*)\}`)
+	return re.ReplaceAllStringFunc(in, func(match string) string {
+		if value != "" {
+			return value
+		}
+		groups := re.FindStringSubmatch(match)
+		return groups[1]
+	})
+}
+
+// substituteDollarBraceAlt replaces "${key:+alt}" with alt if value is
+// non-empty, or with "" otherwise.
+func substituteDollarBraceAlt(in, key, value string) string {
+	re := regexp.MustCompile(`\$\{` + regexp.QuoteMeta(key) + `:\+([^}]*)\}`)
+	return re.ReplaceAllStringFunc(in, func(match string) string {
+		if value == "" {
+			return ""
+		}
+		groups := re.FindStringSubmatch(match)
+		return groups[1]
+	})
+}
+
+// substituteDollarBraceReplace replaces "${key/pattern/replacement}" with
+// value after substituting the first occurrence of pattern with
+// replacement, mirroring bash's single-slash form.
+func substituteDollarBraceReplace(in, key, value string) string {
+	re := regexp.MustCompile(`\$\{` + regexp.QuoteMeta(key) + `/([^/}]*)/([^}]*)\}`)
+	return re.ReplaceAllStringFunc(in, func(match string) string {
+		groups := re.FindStringSubmatch(match)
+		pattern, replacement := groups[1], groups[2]
+		return strings.Replace(value, pattern, replacement, 1)
+	})
+}
+
+// templateData backs the {{if}}/{{range}}/{{with}} template pass, exposing
+// built-ins alongside the raw Variables/Files maps so conditional prompt
+// assembly (e.g. "include a Go checklist only if {{.CurrentFile}} ends in
+// .go", written {{if hasSuffix .CurrentFile ".go"}}...{{end}}) doesn't
+// require authors to duplicate skill files.
+type templateData struct {
+	Cwd         string
+	ProjectRoot string
+	CurrentFile string
+	Selection   string
+	Variables   map[string]string
+	Files       map[string]string
+}
+
+// ExpandVariables expands variables in skill content. It first runs the
+// ordered substitutor pipeline over "{{key}}" and bash-style "${key}"
+// placeholders for every built-in and custom variable (keys are processed
+// longest-first so "{{project_root_docs}}" isn't eaten by
+// "{{project_root}}"), then runs a text/template pass over the result so
+// "{{if}}/{{range}}/{{with}}" blocks referencing the same data are
+// evaluated.
+func (sc *SkillContext) ExpandVariables(content string) string {
+	values := map[string]string{
+		"cwd":          sc.WorkingDir,
+		"project_root": sc.ProjectRoot,
+		"current_file": sc.CurrentFile,
+		"selection":    sc.SelectionText,
+	}
+	for k, v := range sc.Variables {
+		values[k] = v
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+
+	for _, key := range keys {
+		value := values[key]
+		for _, sub := range variableSubstitutors {
+			content = sub(content, key, value)
+		}
+	}
+
+	return sc.expandTemplateBlocks(content)
+}
+
+// expandTemplateBlocks runs content through text/template for its
+// "{{if}}/{{range}}/{{with}}" control-flow blocks. Content with no such
+// blocks (the common case) parses and executes as a no-op. A parse or
+// execution error leaves content untouched rather than failing the whole
+// expansion, since most skills never use this and malformed template
+// syntax shouldn't be fatal.
+func (sc *SkillContext) expandTemplateBlocks(content string) string {
+	tmpl, err := template.New("skill").Funcs(template.FuncMap{"hasSuffix": strings.HasSuffix}).Parse(content)
+	if err != nil {
+		return content
+	}
+
+	data := templateData{
+		Cwd:         sc.WorkingDir,
+		ProjectRoot: sc.ProjectRoot,
+		CurrentFile: sc.CurrentFile,
+		Selection:   sc.SelectionText,
+		Variables:   sc.Variables,
+		Files:       sc.Files,
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return content
+	}
+
+	return buf.String()
+}
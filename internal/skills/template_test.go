@@ -0,0 +1,141 @@
+package skills
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSubstituteDollarBraceDefault(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		key   string
+		value string
+		want  string
+	}{
+		{
+			name:  "falls back to default when value is empty",
+			in:    "branch: ${branch:-main}",
+			key:   "branch",
+			value: "",
+			want:  "branch: main",
+		},
+		{
+			name:  "uses value when non-empty",
+			in:    "branch: ${branch:-main}",
+			key:   "branch",
+			value: "release",
+			want:  "branch: release",
+		},
+		{
+			name:  "leaves unrelated placeholders untouched",
+			in:    "${other:-x}",
+			key:   "branch",
+			value: "release",
+			want:  "${other:-x}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := substituteDollarBraceDefault(tt.in, tt.key, tt.value); got != tt.want {
+				t.Errorf("substituteDollarBraceDefault(%q, %q, %q) = %q, want %q", tt.in, tt.key, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubstituteDollarBraceReplace(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		key   string
+		value string
+		want  string
+	}{
+		{
+			name:  "replaces first occurrence of pattern",
+			in:    "${path/src/dist}",
+			key:   "path",
+			value: "src/main.go",
+			want:  "dist/main.go",
+		},
+		{
+			name:  "no match leaves value unchanged",
+			in:    "${path/foo/bar}",
+			key:   "path",
+			value: "src/main.go",
+			want:  "src/main.go",
+		},
+		{
+			name:  "leaves unrelated placeholders untouched",
+			in:    "${other/a/b}",
+			key:   "path",
+			value: "src/main.go",
+			want:  "${other/a/b}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := substituteDollarBraceReplace(tt.in, tt.key, tt.value); got != tt.want {
+				t.Errorf("substituteDollarBraceReplace(%q, %q, %q) = %q, want %q", tt.in, tt.key, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandVariablesTemplateIf(t *testing.T) {
+	sc := NewSkillContext(context.Background(), ".")
+	sc.CurrentFile = "main.go"
+
+	got := sc.ExpandVariables("{{if .CurrentFile}}current file: {{.CurrentFile}}{{end}}")
+	want := "current file: main.go"
+	if got != want {
+		t.Errorf("ExpandVariables() = %q, want %q", got, want)
+	}
+}
+
+func TestExpandVariablesTemplateIfFalse(t *testing.T) {
+	sc := NewSkillContext(context.Background(), ".")
+
+	got := sc.ExpandVariables("{{if .CurrentFile}}current file: {{.CurrentFile}}{{end}}no file")
+	want := "no file"
+	if got != want {
+		t.Errorf("ExpandVariables() = %q, want %q", got, want)
+	}
+}
+
+func TestExpandVariablesTemplateRange(t *testing.T) {
+	sc := NewSkillContext(context.Background(), ".")
+	sc.Files["a.go"] = "package a"
+	sc.Files["b.go"] = "package b"
+
+	got := sc.ExpandVariables("{{range $name, $content := .Files}}{{$name}}={{$content}};{{end}}")
+	if !containsSubstring(got, "a.go=package a;") || !containsSubstring(got, "b.go=package b;") {
+		t.Errorf("ExpandVariables() = %q, want entries for both a.go and b.go", got)
+	}
+}
+
+func TestExpandVariablesTemplateWith(t *testing.T) {
+	sc := NewSkillContext(context.Background(), ".")
+	sc.Variables["username"] = "alice"
+
+	got := sc.ExpandVariables("{{with .Variables.username}}hello {{.}}{{end}}")
+	want := "hello alice"
+	if got != want {
+		t.Errorf("ExpandVariables() = %q, want %q", got, want)
+	}
+}
+
+func TestExpandVariablesLongestKeyFirst(t *testing.T) {
+	sc := NewSkillContext(context.Background(), ".")
+	sc.ProjectRoot = "/project/root"
+	sc.Variables["project_root_docs"] = "/project/root/docs"
+
+	got := sc.ExpandVariables("{{project_root_docs}} {{project_root}}")
+	want := "/project/root/docs /project/root"
+	if got != want {
+		t.Errorf("ExpandVariables() = %q, want %q (project_root_docs must not be eaten by project_root)", got, want)
+	}
+}
@@ -0,0 +1,218 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// debounceWindow is how long Watch waits after the last fsnotify event for
+// a given file before re-parsing it, so editors that emit several events
+// per save (write, chmod, rename-over-target) only trigger one reload.
+const debounceWindow = 250 * time.Millisecond
+
+// SkillChangeEventType identifies the kind of change a SkillChangeEvent
+// describes.
+type SkillChangeEventType string
+
+const (
+	SkillChangeAdded    SkillChangeEventType = "added"
+	SkillChangeModified SkillChangeEventType = "modified"
+	SkillChangeRemoved  SkillChangeEventType = "removed"
+)
+
+// SkillChangeEvent reports a skill that was added, modified, or removed
+// while Watch was running, so the orchestrator/UI can surface a toast and
+// invalidate any cached prompts for it.
+type SkillChangeEvent struct {
+	Type   SkillChangeEventType
+	Name   string
+	Source SkillSource
+	Path   string
+	Err    error
+}
+
+// Events returns the channel Watch publishes SkillChangeEvent values to.
+// It is safe to call before Watch starts; events only begin arriving once
+// Watch is running.
+func (l *SkillLoader) Events() <-chan SkillChangeEvent {
+	return l.events
+}
+
+// Watch installs recursive fsnotify watchers on every configured source
+// directory and re-parses changed skill files as they're edited, so a
+// long-running orchestrator session picks up skill edits without a restart.
+// Changed files are debounced by debounceWindow before being re-registered;
+// deletions call registry.Remove. Watch blocks until ctx is cancelled.
+func (l *SkillLoader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	pathSources := make(map[string]SkillSource)
+
+	for source, dir := range l.sources {
+		if dir == "" {
+			continue
+		}
+		if err := addWatchRecursive(watcher, dir, source, pathSources); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to watch %s (%s): %w", source, dir, err)
+		}
+	}
+
+	var mu sync.Mutex
+	timers := make(map[string]*time.Timer)
+
+	handle := func(event fsnotify.Event) {
+		ext := strings.ToLower(filepath.Ext(event.Name))
+		info, statErr := os.Stat(event.Name)
+		isDir := statErr == nil && info.IsDir()
+
+		if isDir {
+			if event.Op&(fsnotify.Create) != 0 {
+				source := sourceForPath(pathSources, event.Name)
+				_ = addWatchRecursive(watcher, event.Name, source, pathSources)
+			}
+			return
+		}
+
+		if ext != ".md" && ext != ".markdown" {
+			return
+		}
+
+		mu.Lock()
+		if t, ok := timers[event.Name]; ok {
+			t.Stop()
+		}
+		timers[event.Name] = time.AfterFunc(debounceWindow, func() {
+			mu.Lock()
+			delete(timers, event.Name)
+			mu.Unlock()
+			l.handleWatchEvent(ctx, event, sourceForPath(pathSources, event.Name))
+		})
+		mu.Unlock()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			handle(event)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			l.emit(ctx, SkillChangeEvent{Err: err})
+		}
+	}
+}
+
+// emit sends evt to l.events, but gives up once ctx is cancelled instead of
+// blocking forever against a stalled or absent consumer - a debounce timer
+// can still fire after Watch itself has returned.
+func (l *SkillLoader) emit(ctx context.Context, evt SkillChangeEvent) {
+	select {
+	case l.events <- evt:
+	case <-ctx.Done():
+	}
+}
+
+// handleWatchEvent re-parses or removes a skill after its debounce window
+// elapses.
+func (l *SkillLoader) handleWatchEvent(ctx context.Context, event fsnotify.Event, source SkillSource) {
+	if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+		if _, err := os.Stat(event.Name); err == nil {
+			// Atomic-write pattern: a rename-over-target looks like a
+			// removal of the old inode followed immediately by a create
+			// of the new one. The file still exists, so treat it as a
+			// modification rather than a deletion.
+			l.reloadWatchedFile(ctx, event.Name, source, SkillChangeModified)
+			return
+		}
+
+		name := skillNameForPath(l.registry, event.Name)
+		if name != "" && l.registry.Remove(name) {
+			l.emit(ctx, SkillChangeEvent{Type: SkillChangeRemoved, Name: name, Source: source, Path: event.Name})
+		}
+		return
+	}
+
+	l.reloadWatchedFile(ctx, event.Name, source, SkillChangeModified)
+}
+
+// reloadWatchedFile parses and registers a changed skill file, reporting it
+// as added if it wasn't previously known to the registry.
+func (l *SkillLoader) reloadWatchedFile(ctx context.Context, path string, source SkillSource, changeType SkillChangeEventType) {
+	skill, err := l.loadSkillFile(path, source)
+	if err != nil {
+		l.emit(ctx, SkillChangeEvent{Err: err, Source: source, Path: path})
+		return
+	}
+
+	if !l.registry.Exists(skill.Name) {
+		changeType = SkillChangeAdded
+	}
+
+	l.registry.Register(skill)
+	l.emit(ctx, SkillChangeEvent{Type: changeType, Name: skill.Name, Source: source, Path: path})
+}
+
+// addWatchRecursive adds w to dir and every subdirectory beneath it,
+// recording each watched path's source so later events can be attributed
+// back to it.
+func addWatchRecursive(w *fsnotify.Watcher, dir string, source SkillSource, pathSources map[string]SkillSource) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if err := w.Add(path); err != nil {
+			return err
+		}
+		pathSources[path] = source
+		return nil
+	})
+}
+
+// sourceForPath finds the configured source a watched path falls under,
+// walking up to parent directories since pathSources records only the
+// watched directories, while events name the changed file inside them.
+func sourceForPath(pathSources map[string]SkillSource, path string) SkillSource {
+	dir := filepath.Dir(path)
+	for {
+		if source, ok := pathSources[dir]; ok {
+			return source
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// skillNameForPath finds the registered skill name backed by a given file
+// path, so a removal event (which only carries a path) can be translated
+// into a registry.Remove call.
+func skillNameForPath(registry *SkillRegistry, path string) string {
+	for _, skill := range registry.List() {
+		if skill.Path == path {
+			return skill.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package skills
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch itself blocks on a live fsnotify event loop, so these tests target
+// its unexported helpers directly rather than racing real filesystem
+// events.
+
+func TestReloadWatchedFileRegistersNewSkillAsAdded(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "greet.md")
+	writeSkillFile(t, dir, "greet.md", "---\nname: greet\ndescription: says hello\n---\nHello\n")
+
+	registry := NewSkillRegistry()
+	loader := NewSkillLoader(registry)
+	loader.reloadWatchedFile(context.Background(), path, SourceProject, SkillChangeModified)
+
+	select {
+	case evt := <-loader.Events():
+		if evt.Type != SkillChangeAdded {
+			t.Errorf("event type = %q, want %q", evt.Type, SkillChangeAdded)
+		}
+		if evt.Name != "greet" {
+			t.Errorf("event name = %q, want %q", evt.Name, "greet")
+		}
+	default:
+		t.Fatal("expected an event to be published")
+	}
+
+	if !registry.Exists("greet") {
+		t.Error("expected the skill to be registered")
+	}
+}
+
+func TestReloadWatchedFileRegistersKnownSkillAsModified(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "greet.md")
+	writeSkillFile(t, dir, "greet.md", "---\nname: greet\ndescription: says hello\n---\nHello\n")
+
+	registry := NewSkillRegistry()
+	registry.Register(&Skill{Name: "greet", Path: path})
+	loader := NewSkillLoader(registry)
+	loader.reloadWatchedFile(context.Background(), path, SourceProject, SkillChangeModified)
+
+	evt := <-loader.Events()
+	if evt.Type != SkillChangeModified {
+		t.Errorf("event type = %q, want %q", evt.Type, SkillChangeModified)
+	}
+}
+
+func TestReloadWatchedFilePublishesErrorEventOnParseFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "broken.md")
+	writeSkillFile(t, dir, "broken.md", "---\ndescription: no name\n---\nbody\n")
+
+	registry := NewSkillRegistry()
+	loader := NewSkillLoader(registry)
+	loader.reloadWatchedFile(context.Background(), path, SourceProject, SkillChangeModified)
+
+	evt := <-loader.Events()
+	if evt.Err == nil {
+		t.Fatal("expected an error event for a skill file that fails to parse")
+	}
+}
+
+func TestEmitGivesUpWhenContextIsCancelled(t *testing.T) {
+	loader := NewSkillLoader(NewSkillRegistry())
+
+	// Fill the events buffer so a further send would block forever without
+	// the ctx.Done() escape hatch.
+	for i := 0; i < cap(loader.events); i++ {
+		loader.events <- SkillChangeEvent{Name: "filler"}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		loader.emit(ctx, SkillChangeEvent{Name: "dropped"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("emit blocked on a full channel instead of giving up on ctx.Done()")
+	}
+}
+
+func TestSkillNameForPathFindsRegisteredSkillByPath(t *testing.T) {
+	registry := NewSkillRegistry()
+	registry.Register(&Skill{Name: "greet", Path: "/skills/greet.md"})
+
+	if got := skillNameForPath(registry, "/skills/greet.md"); got != "greet" {
+		t.Errorf("skillNameForPath() = %q, want %q", got, "greet")
+	}
+	if got := skillNameForPath(registry, "/skills/missing.md"); got != "" {
+		t.Errorf("skillNameForPath() = %q, want empty string", got)
+	}
+}
+
+func TestSourceForPathWalksUpToNearestWatchedAncestor(t *testing.T) {
+	pathSources := map[string]SkillSource{
+		"/project/.claude/skills": SourceProject,
+	}
+
+	got := sourceForPath(pathSources, "/project/.claude/skills/nested/greet.md")
+	if got != SourceProject {
+		t.Errorf("sourceForPath() = %q, want %q", got, SourceProject)
+	}
+
+	if got := sourceForPath(pathSources, "/unrelated/greet.md"); got != "" {
+		t.Errorf("sourceForPath() = %q, want empty SkillSource", got)
+	}
+}
+
+func TestAddWatchRecursiveRecordsEverySubdirectory(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("failed to create nested dir: %v", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	pathSources := make(map[string]SkillSource)
+	if err := addWatchRecursive(watcher, dir, SourceProject, pathSources); err != nil {
+		t.Fatalf("addWatchRecursive returned unexpected error: %v", err)
+	}
+
+	if pathSources[dir] != SourceProject {
+		t.Errorf("pathSources[%q] = %q, want %q", dir, pathSources[dir], SourceProject)
+	}
+	if pathSources[nested] != SourceProject {
+		t.Errorf("pathSources[%q] = %q, want %q", nested, pathSources[nested], SourceProject)
+	}
+}